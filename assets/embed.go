@@ -2,10 +2,10 @@ package assets
 
 import (
 	"bytes"
-	_ "embed"
+	"embed"
 	"encoding/gob"
 	"encoding/json"
-	"log"
+	"fmt"
 )
 
 var (
@@ -13,35 +13,41 @@ var (
 	WordlesFile []byte
 	//go:embed nonwordles.json
 	NonwordlesFile []byte
-	//go:embed solution-tree.bin
-	SolutionTreeFile []byte
-	//go:embed guess-tree.bin
-	GuessTreeFile []byte
-	//go:embed constraint-map.bin
-	ConstraintMapFile []byte
-
-	Wordles              = LoadJsonStringArray(WordlesFile)
-	NonWordles           = LoadJsonStringArray(NonwordlesFile)
-	WordlesAndNonWordles = append(Wordles, NonWordles...)
+
+	// Precomputed for the default word length of 5, these are optional as
+	// they can be computed at runtime, though this may take a while.
+	//go:embed solution-tree-5.bin guess-tree-5.bin strategy-5.bin
+	precomputed embed.FS
 )
 
-func LoadJsonStringArray(file []byte) []string {
+// File name of a precomputed asset, e.g. 'solution-tree-5.bin'
+func PrecomputedName(name string, wordLength int) string {
+	return fmt.Sprintf("%s-%d.bin", name, wordLength)
+}
+
+// Reads an embedded precomputed asset, the error wraps
+// fs.ErrNotExist if the asset has not been precomputed.
+func ReadPrecomputed(name string, wordLength int) ([]byte, error) {
+	return precomputed.ReadFile(PrecomputedName(name, wordLength))
+}
+
+func LoadJsonStringArray(file []byte) ([]string, error) {
 	var wordles []string
 	if err := json.Unmarshal(file, &wordles); err != nil {
-		log.Fatalf("Failed to parse embedded JSON: %v", err)
+		return nil, fmt.Errorf("Failed to parse JSON: %w", err)
 	}
 
-	return wordles
+	return wordles, nil
 }
 
-func Load[T any](data []byte) T {
+func Load[T any](data []byte) (T, error) {
 	buffer := bytes.NewBuffer(data)
 	dec := gob.NewDecoder(buffer)
 
 	var obj T
 	if err := dec.Decode(&obj); err != nil {
-		log.Fatalf("Failed to decode struct: %v", err)
+		return obj, fmt.Errorf("Failed to decode struct: %w", err)
 	}
 
-	return obj
+	return obj, nil
 }
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Backshifted/wordle-solver/cmd/internal/cliflags"
+	"github.com/Backshifted/wordle-solver/pkg/wordle"
+)
+
+type (
+	result struct {
+		Word     string        `json:"word"`
+		Guesses  []string      `json:"guesses"`
+		Solved   bool          `json:"solved"`
+		Duration time.Duration `json:"duration_ns"`
+	}
+	summary struct {
+		Words    int `json:"words"`
+		Failures int `json:"failures"`
+		// Average number of guesses of the solved words
+		Mean float64 `json:"mean"`
+		// Number of solved words by number of guesses
+		Histogram map[int]int   `json:"histogram"`
+		Duration  time.Duration `json:"duration_ns"`
+		Results   []result      `json:"results"`
+	}
+)
+
+// Bounds the adversarial game in case the solver stops making progress
+const adversarialMaxGuesses = 100
+
+func main() {
+	var dictionaryFlags cliflags.DictionaryFlags
+	dictionaryFlags.Register(flag.CommandLine)
+	wordsFlag := flag.String("words", "", "comma separated words to benchmark, defaults to all solutions")
+	limit := flag.Int("limit", 0, "benchmark only the first n words")
+	firstGuess := flag.String("first", "", "first guess, defaults to the solver's best guess")
+	hardMode := flag.Bool("hard", false, "play in hard mode")
+	useStrategy := flag.Bool("strategy", false, "follow the precomputed strategy while possible")
+	adversarial := flag.Bool("adversarial", false, "play a single game against an adversary that avoids committing to a word, i.e. the worst case")
+	scorerName := flag.String("scorer", "entropy", "ranks the guesses, one of: "+strings.Join(wordle.ScorerNames(), ", "))
+	numSlowest := flag.Int("slowest", 10, "number of slowest words to report")
+	jsonPath := flag.String("json", "", "write the results as JSON to this file")
+	csvPath := flag.String("csv", "", "write the results as CSV to this file")
+	flag.Parse()
+
+	dictionary, priors, err := dictionaryFlags.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	scorer, err := wordle.ParseScorer(*scorerName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := []wordle.Option{wordle.WithDictionary(dictionary), wordle.WithScorer(scorer), wordle.WithPriors(priors)}
+	if *hardMode {
+		opts = append(opts, wordle.WithHardMode())
+	}
+
+	words := dictionary.Wordles
+	if *wordsFlag != "" {
+		words = strings.Split(strings.ToLower(*wordsFlag), ",")
+	}
+	if *limit > 0 && *limit < len(words) {
+		words = words[:*limit]
+	}
+
+	// The first guess is the same for every word, only compute it once
+	if *firstGuess == "" && !*useStrategy {
+		solver, err := wordle.NewSolver(opts...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		*firstGuess = solver.BestGuess()
+		fmt.Printf("First guess: %s\n", *firstGuess)
+	}
+
+	if *adversarial {
+		// Absurdle has no guess limit, the worst case may well exceed the default
+		opts = append(opts, wordle.WithMaxGuesses(adversarialMaxGuesses))
+		game, err := wordle.NewAdversarialGame(opts...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		r, err := play(game, *firstGuess, *useStrategy, opts)
+		if err != nil {
+			log.Fatalf("Failed to play the adversary: %v", err)
+		}
+		printAdversarial(r)
+		return
+	}
+
+	s := summary{Words: len(words), Histogram: make(map[int]int)}
+	start := time.Now()
+	for i, word := range words {
+		game, err := wordle.NewGame(word, opts...)
+		if err != nil {
+			log.Fatalf("Failed to play '%s': %v", word, err)
+		}
+		r, err := play(game, *firstGuess, *useStrategy, opts)
+		if err != nil {
+			log.Fatalf("Failed to play '%s': %v", word, err)
+		}
+
+		s.Results = append(s.Results, r)
+		if r.Solved {
+			s.Histogram[len(r.Guesses)]++
+			s.Mean += float64(len(r.Guesses))
+		} else {
+			s.Failures++
+		}
+		fmt.Printf("\r%d/%d", i+1, len(words))
+	}
+	fmt.Println()
+	s.Duration = time.Since(start)
+	if solved := s.Words - s.Failures; solved > 0 {
+		s.Mean /= float64(solved)
+	}
+
+	printSummary(s, *numSlowest)
+	if *jsonPath != "" {
+		writeJson(s, *jsonPath)
+	}
+	if *csvPath != "" {
+		writeCsv(s, *csvPath)
+	}
+}
+
+// Plays the game, always guessing the solver's best guess
+func play(game *wordle.Game, firstGuess string, useStrategy bool, opts []wordle.Option) (r result, err error) {
+	// Adversarial games only commit to a word once the game is over
+	defer func() { r.Word = game.Word() }()
+	solver, err := wordle.NewSolver(opts...)
+	if err != nil {
+		return r, err
+	}
+
+	start := time.Now()
+	defer func() { r.Duration = time.Since(start) }()
+
+	guess := firstGuess
+	for !game.Over() {
+		if strategyGuess, ok := solver.StrategyGuess(); useStrategy && ok {
+			guess = strategyGuess
+		} else if guess == "" {
+			guess = solver.BestGuess()
+		}
+		if guess == "" {
+			break
+		}
+
+		done, err := game.Guess(guess)
+		if err != nil {
+			return r, fmt.Errorf("guess '%s': %w", guess, err)
+		}
+		r.Guesses = append(r.Guesses, guess)
+		if done {
+			r.Solved = true
+			break
+		}
+
+		guesses := game.Guesses()
+		if _, err := solver.AddGuess(guess, guesses[len(guesses)-1].Hints()); err != nil {
+			return r, fmt.Errorf("guess '%s': %w", guess, err)
+		}
+		guess = ""
+	}
+
+	return r, nil
+}
+
+func printAdversarial(r result) {
+	if r.Solved {
+		fmt.Printf("Solved     : %d guesses\n", len(r.Guesses))
+	} else {
+		fmt.Printf("Failed     : %s remained\n", r.Word)
+	}
+	fmt.Printf("Duration   : %s\n", r.Duration.Round(time.Millisecond))
+	fmt.Printf("Guesses    : %s\n", strings.Join(r.Guesses, " "))
+}
+
+func printSummary(s summary, numSlowest int) {
+	fmt.Printf("Words      : %d\n", s.Words)
+	fmt.Printf("Mean       : %.4f guesses\n", s.Mean)
+	fmt.Printf("Failures   : %d\n", s.Failures)
+	fmt.Printf("Duration   : %s\n", s.Duration.Round(time.Millisecond))
+	fmt.Println("Histogram  :")
+	for guesses := 1; guesses <= wordle.MaxGuesses; guesses++ {
+		fmt.Printf("%10d : %d\n", guesses, s.Histogram[guesses])
+	}
+
+	slowest := slices.Clone(s.Results)
+	slices.SortFunc(slowest, func(a result, b result) int {
+		return int(b.Duration - a.Duration)
+	})
+	fmt.Println("Slowest    :")
+	for _, r := range slowest[:min(numSlowest, len(slowest))] {
+		fmt.Printf("%10s : %s  %s\n", r.Word, r.Duration.Round(time.Millisecond), strings.Join(r.Guesses, " "))
+	}
+}
+
+func writeJson(s summary, path string) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("Unable to open file '%s': %v", path, err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		log.Fatal("Encoding error:", err)
+	}
+
+	fmt.Printf("Wrote results to '%s'\n", path)
+}
+
+func writeCsv(s summary, path string) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("Unable to open file '%s': %v", path, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"word", "num_guesses", "solved", "duration_ms", "guesses"})
+	for _, r := range s.Results {
+		w.Write([]string{
+			r.Word,
+			strconv.Itoa(len(r.Guesses)),
+			strconv.FormatBool(r.Solved),
+			strconv.FormatInt(r.Duration.Milliseconds(), 10),
+			strings.Join(r.Guesses, " "),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Fatal("Encoding error:", err)
+	}
+
+	fmt.Printf("Wrote results to '%s'\n", path)
+}
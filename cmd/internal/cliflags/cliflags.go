@@ -0,0 +1,43 @@
+// Command line flags shared by the commands
+package cliflags
+
+import (
+	"flag"
+
+	"github.com/Backshifted/wordle-solver/pkg/wordle"
+)
+
+// Command line flags of the word lists and priors, shared by the commands
+type DictionaryFlags struct {
+	WordlesPath    string
+	NonWordlesPath string
+	PriorsPath     string
+	MatrixPath     string
+}
+
+// Defines the '-wordles', '-nonwordles', '-priors' and '-matrix' flags
+func (f *DictionaryFlags) Register(flags *flag.FlagSet) {
+	flags.StringVar(&f.WordlesPath, "wordles", "", "word list of solutions, defaults to the Wordle solutions")
+	flags.StringVar(&f.NonWordlesPath, "nonwordles", "", "word list of additional guesses")
+	flags.StringVar(&f.PriorsPath, "priors", "", "word frequencies weighing the solutions, see README")
+	flags.StringVar(&f.MatrixPath, "matrix", "", "pattern matrix written by the precompute command, computed if empty")
+}
+
+// Loads the dictionary, pattern matrix and priors of the flags, see wordle.LoadDictionary and wordle.LoadPriors
+func (f *DictionaryFlags) Load() (*wordle.Dictionary, wordle.Priors, error) {
+	dictionary, err := wordle.LoadDictionary(f.WordlesPath, f.NonWordlesPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if f.MatrixPath != "" {
+		if err := dictionary.LoadPatternMatrix(f.MatrixPath); err != nil {
+			return nil, nil, err
+		}
+	}
+	priors, err := wordle.LoadPriors(f.PriorsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dictionary, priors, nil
+}
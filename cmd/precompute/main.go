@@ -2,23 +2,84 @@ package main
 
 import (
 	"encoding/gob"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/Backshifted/wordle-solver/assets"
 	"github.com/Backshifted/wordle-solver/pkg/wordle"
 )
 
 func main() {
-	solutionTree := wordle.NewWordleTree(assets.Wordles, wordle.NewConstraint())
-	writeObject(solutionTree, "assets/solution-tree.bin")
+	wordlesPath := flag.String("wordles", "assets/wordles.json", "word list of solutions")
+	nonWordlesPath := flag.String("nonwordles", "assets/nonwordles.json", "word list of additional guesses")
+	outDir := flag.String("out", "assets", "output directory")
+	matrixPath := flag.String("matrix", "", "also write the pattern matrix to this file, it is loaded with -matrix by the other commands")
+	strategy := flag.Bool("strategy", false, "precompute the guess strategy instead of the trees")
+	breadth := flag.Int("breadth", 10, "number of best guesses to look ahead for at each node of the strategy")
+	firstGuess := flag.String("first", "", "first guess of the strategy, searched for if empty")
+	reportPath := flag.String("report", "", "only report the stats of a precomputed strategy")
+	flag.Parse()
 
-	guessTree := wordle.NewWordleTree(assets.WordlesAndNonWordles, wordle.NewConstraint())
-	writeObject(guessTree, "assets/guess-tree.bin")
+	if *reportPath != "" {
+		s, err := wordle.LoadStrategy(*reportPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		report(s)
+		return
+	}
+
+	dictionary, err := wordle.LoadDictionary(*wordlesPath, *nonWordlesPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *strategy {
+		start := time.Now()
+		s, err := wordle.BuildStrategy(dictionary, wordle.StrategyOptions{Breadth: *breadth, FirstGuess: *firstGuess})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Built strategy in %s\n", time.Since(start).Round(time.Millisecond))
+
+		writeObject(s, assetPath(*outDir, "strategy", dictionary.WordLength))
+		report(s)
+		return
+	}
+
+	wordLength := dictionary.WordLength
+	wordlesAndNonWordles := append(slices.Clone(dictionary.Wordles), dictionary.NonWordles...)
+
+	solutionTree := wordle.NewWordleTree(wordLength, dictionary.Wordles, wordle.NewConstraint())
+	writeObject(solutionTree, assetPath(*outDir, "solution-tree", wordLength))
+
+	guessTree := wordle.NewWordleTree(wordLength, wordlesAndNonWordles, wordle.NewConstraint())
+	writeObject(guessTree, assetPath(*outDir, "guess-tree", wordLength))
+
+	if *matrixPath != "" {
+		patternMatrix := wordle.NewPatternMatrix(wordLength, guessTree.Wordles, solutionTree.Wordles)
+		writeObject(patternMatrix, *matrixPath)
+	}
+}
+
+func report(s *wordle.Strategy) {
+	stats := s.Stats()
+	fmt.Printf("First guess  : %s\n", s.Root.Guess)
+	fmt.Printf("Solutions    : %d\n", stats.Solutions)
+	fmt.Printf("Average      : %.4f guesses\n", stats.Average)
+	fmt.Printf("Worst case   : %d guesses\n", stats.Worst)
+	for guesses := 1; guesses <= stats.Worst; guesses++ {
+		fmt.Printf("%12d : %d\n", guesses, stats.Histogram[guesses])
+	}
+}
 
-	guessConstraintMap := wordle.NewConstraintMap(assets.WordlesAndNonWordles, wordle.SolutionTree)
-	writeObject(guessConstraintMap, "assets/constraint-map.bin")
+func assetPath(dir string, name string, wordLength int) string {
+	return filepath.Join(dir, assets.PrecomputedName(name, wordLength))
 }
 
 func writeObject(obj any, path string) {
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Backshifted/wordle-solver/cmd/internal/cliflags"
+	"github.com/Backshifted/wordle-solver/pkg/wordle"
+)
+
+const defaultSuggestions = 10
+
+type (
+	server struct {
+		dictionary *wordle.Dictionary
+		priors     wordle.Priors
+		timeout    time.Duration
+		solvers    *sessionStore[*wordle.Solver]
+		games      *sessionStore[*wordle.Game]
+	}
+
+	createRequest struct {
+		HardMode bool `json:"hard_mode"`
+		// Only for solvers, defaults to entropy
+		Scorer string `json:"scorer"`
+		// Only for games, defaults to a random solution
+		Word string `json:"word"`
+	}
+	guessRequest struct {
+		Word string `json:"word"`
+		// Only for solvers, e.g. 'gy---'
+		Hints string `json:"hints"`
+	}
+
+	guessResponse struct {
+		Word  string `json:"word"`
+		Hints string `json:"hints"`
+	}
+	suggestionResponse struct {
+		Word    string  `json:"word"`
+		Utility float64 `json:"utility"`
+		// Probability that the suggestion is the solution
+		Probability float64 `json:"probability"`
+	}
+	solverResponse struct {
+		Id          string               `json:"id"`
+		Guesses     []guessResponse      `json:"guesses"`
+		Entropy     float64              `json:"entropy"`
+		Candidates  []string             `json:"candidates"`
+		Strategy    string               `json:"strategy,omitempty"`
+		Suggestions []suggestionResponse `json:"suggestions"`
+		// Whether the suggestions are the best of the guesses scored before the timeout
+		Partial bool `json:"partial,omitempty"`
+	}
+	// Never includes the hidden word
+	gameResponse struct {
+		Id      string          `json:"id"`
+		Guesses []guessResponse `json:"guesses"`
+		Solved  bool            `json:"solved"`
+		Over    bool            `json:"over"`
+	}
+	errorResponse struct {
+		Error string `json:"error"`
+		// Identifies rejected guesses, see guessErrorCodes
+		Code string `json:"code,omitempty"`
+		// Allowed guesses within one letter of an unknown word
+		Suggestions []string `json:"suggestions,omitempty"`
+	}
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	var dictionaryFlags cliflags.DictionaryFlags
+	dictionaryFlags.Register(flag.CommandLine)
+	ttl := flag.Duration("ttl", time.Hour, "expire sessions unused for this long")
+	timeout := flag.Duration("timeout", 2*time.Second, "return the best suggestions found within this time")
+	flag.Parse()
+	if *ttl <= 0 {
+		log.Fatalf("Invalid ttl %s, should be positive", *ttl)
+	}
+
+	dictionary, priors, err := dictionaryFlags.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s := &server{
+		dictionary: dictionary,
+		priors:     priors,
+		timeout:    *timeout,
+		solvers:    newSessionStore[*wordle.Solver](),
+		games:      newSessionStore[*wordle.Game](),
+	}
+
+	// Expire at most once a second, time.Tick returns nil for ttls under 10ns
+	go func() {
+		for range time.Tick(max(*ttl/10, time.Second)) {
+			before := time.Now().Add(-*ttl)
+			if expired := s.solvers.expire(before) + s.games.expire(before); expired > 0 {
+				log.Printf("Expired %d sessions", expired)
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /solvers", s.createSolver)
+	mux.HandleFunc("GET /solvers/{id}", s.getSolver)
+	mux.HandleFunc("POST /solvers/{id}/guesses", s.guessSolver)
+	mux.HandleFunc("DELETE /solvers/{id}", s.deleteSolver)
+	mux.HandleFunc("POST /games", s.createGame)
+	mux.HandleFunc("GET /games/{id}", s.getGame)
+	mux.HandleFunc("POST /games/{id}/guesses", s.guessGame)
+	mux.HandleFunc("DELETE /games/{id}", s.deleteGame)
+
+	log.Printf("Listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+func (s *server) options(hardMode bool) []wordle.Option {
+	opts := []wordle.Option{wordle.WithDictionary(s.dictionary), wordle.WithPriors(s.priors)}
+	if hardMode {
+		opts = append(opts, wordle.WithHardMode())
+	}
+
+	return opts
+}
+
+func (s *server) createSolver(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if !readJson(w, r, &req) {
+		return
+	}
+
+	opts := s.options(req.HardMode)
+	if req.Scorer != "" {
+		scorer, err := wordle.ParseScorer(req.Scorer)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		opts = append(opts, wordle.WithScorer(scorer))
+	}
+
+	solver, err := wordle.NewSolver(opts...)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	id := s.solvers.create(&solver)
+	writeJson(w, http.StatusCreated, s.newSolverResponse(r, id, &solver))
+}
+
+func (s *server) getSolver(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.solvers.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("Unknown solver"))
+		return
+	}
+
+	sess.Lock()
+	defer sess.Unlock()
+	writeJson(w, http.StatusOK, s.newSolverResponse(r, r.PathValue("id"), sess.value))
+}
+
+func (s *server) guessSolver(w http.ResponseWriter, r *http.Request) {
+	var req guessRequest
+	if !readJson(w, r, &req) {
+		return
+	}
+	sess, ok := s.solvers.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("Unknown solver"))
+		return
+	}
+
+	sess.Lock()
+	defer sess.Unlock()
+
+	word := strings.ToLower(req.Word)
+	hints, err := wordle.ParseExactPattern(req.Hints, len(word))
+	if err != nil {
+		writeGuessError(w, err)
+		return
+	}
+	if _, err := sess.value.AddGuess(word, hints); err != nil {
+		writeGuessError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, s.newSolverResponse(r, r.PathValue("id"), sess.value))
+}
+
+func (s *server) deleteSolver(w http.ResponseWriter, r *http.Request) {
+	if !s.solvers.delete(r.PathValue("id")) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("Unknown solver"))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) createGame(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if !readJson(w, r, &req) {
+		return
+	}
+
+	word := strings.ToLower(req.Word)
+	if word == "" {
+		word = s.dictionary.Wordles[rand.IntN(len(s.dictionary.Wordles))]
+	}
+	game, err := wordle.NewGame(word, s.options(req.HardMode)...)
+	if err != nil {
+		writeGuessError(w, err)
+		return
+	}
+
+	id := s.games.create(game)
+	writeJson(w, http.StatusCreated, newGameResponse(id, game))
+}
+
+func (s *server) getGame(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.games.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("Unknown game"))
+		return
+	}
+
+	sess.Lock()
+	defer sess.Unlock()
+	writeJson(w, http.StatusOK, newGameResponse(r.PathValue("id"), sess.value))
+}
+
+func (s *server) guessGame(w http.ResponseWriter, r *http.Request) {
+	var req guessRequest
+	if !readJson(w, r, &req) {
+		return
+	}
+	sess, ok := s.games.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("Unknown game"))
+		return
+	}
+
+	sess.Lock()
+	defer sess.Unlock()
+
+	if _, err := sess.value.Guess(strings.ToLower(req.Word)); err != nil {
+		writeGuessError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, newGameResponse(r.PathValue("id"), sess.value))
+}
+
+func (s *server) deleteGame(w http.ResponseWriter, r *http.Request) {
+	if !s.games.delete(r.PathValue("id")) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("Unknown game"))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) newSolverResponse(r *http.Request, id string, solver *wordle.Solver) solverResponse {
+	res := solverResponse{
+		Id:          id,
+		Guesses:     newGuessResponses(solver.Guesses()),
+		Entropy:     solver.Entropy(),
+		Candidates:  solver.Candidates(),
+		Suggestions: []suggestionResponse{},
+	}
+	res.Strategy, _ = solver.StrategyGuess()
+	n := numSuggestions(r)
+	if n == 0 {
+		return res
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+	suggestions, err := solver.SuggestionsContext(ctx, n)
+	res.Partial = err != nil
+	for _, utility := range suggestions {
+		res.Suggestions = append(res.Suggestions, suggestionResponse{utility.Word, utility.Utility, utility.Probability})
+	}
+
+	return res
+}
+
+func newGameResponse(id string, game *wordle.Game) gameResponse {
+	return gameResponse{
+		Id:      id,
+		Guesses: newGuessResponses(game.Guesses()),
+		Solved:  game.Solved(),
+		Over:    game.Over(),
+	}
+}
+
+func newGuessResponses(guesses []*wordle.Guess) []guessResponse {
+	res := make([]guessResponse, len(guesses))
+	for i, guess := range guesses {
+		res[i] = guessResponse{guess.Word(), guess.Hints().String()}
+	}
+
+	return res
+}
+
+// Number of suggestions from the 'n' query parameter
+func numSuggestions(r *http.Request) int {
+	if n, err := strconv.Atoi(r.URL.Query().Get("n")); err == nil && n >= 0 {
+		return n
+	}
+
+	return defaultSuggestions
+}
+
+// Decodes the request body into obj, an empty body leaves obj unchanged
+func readJson(w http.ResponseWriter, r *http.Request, obj any) bool {
+	if r.ContentLength == 0 {
+		return true
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16))
+	if err := dec.Decode(obj); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("Invalid JSON: %w", err))
+		return false
+	}
+
+	return true
+}
+
+func writeJson(w http.ResponseWriter, status int, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		log.Printf("Encoding error: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJson(w, status, errorResponse{Error: err.Error()})
+}
+
+// Codes and statuses of the errors of rejected guesses
+var guessErrorCodes = []struct {
+	err    error
+	code   string
+	status int
+}{
+	{wordle.ErrGameOver, "game_over", http.StatusConflict},
+	{wordle.ErrWrongLength, "wrong_length", http.StatusBadRequest},
+	{wordle.ErrInvalidCharacters, "invalid_characters", http.StatusBadRequest},
+	{wordle.ErrUnknownWord, "unknown_word", http.StatusUnprocessableEntity},
+	{wordle.ErrHardModeViolation, "hard_mode_violation", http.StatusUnprocessableEntity},
+}
+
+// Writes the error of a rejected guess or word to guess with its code, other
+// errors such as contradicting hints are invalid input.
+func writeGuessError(w http.ResponseWriter, err error) {
+	res := errorResponse{Error: err.Error()}
+	status := http.StatusBadRequest
+	for _, e := range guessErrorCodes {
+		if errors.Is(err, e.err) {
+			res.Code, status = e.code, e.status
+			break
+		}
+	}
+	var wordErr *wordle.WordError
+	if errors.As(err, &wordErr) {
+		res.Suggestions = wordErr.Suggestions
+	}
+
+	writeJson(w, status, res)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+type (
+	// Sessions are locked while in use, solvers and games are not safe for concurrent use
+	session[T any] struct {
+		sync.Mutex
+		value    T
+		lastUsed time.Time
+	}
+	sessionStore[T any] struct {
+		mu       sync.Mutex
+		sessions map[string]*session[T]
+	}
+)
+
+func newSessionStore[T any]() *sessionStore[T] {
+	return &sessionStore[T]{sessions: make(map[string]*session[T])}
+}
+
+func (s *sessionStore[T]) create(value T) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := newSessionId()
+	s.sessions[id] = &session[T]{value: value, lastUsed: time.Now()}
+	return id
+}
+
+func (s *sessionStore[T]) get(id string) (*session[T], bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[id]
+	if ok {
+		sess.lastUsed = time.Now()
+	}
+
+	return sess, ok
+}
+
+func (s *sessionStore[T]) delete(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.sessions[id]
+	delete(s.sessions, id)
+	return ok
+}
+
+// Removes the sessions unused since before, returns the number of removed sessions
+func (s *sessionStore[T]) expire(before time.Time) (expired int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, sess := range s.sessions {
+		if sess.lastUsed.Before(before) {
+			delete(s.sessions, id)
+			expired++
+		}
+	}
+
+	return expired
+}
+
+// Unguessable, the session id is the only access control
+func newSessionId() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Backshifted/wordle-solver/pkg/wordle"
+)
+
+type (
+	// Repeatable flag of guesses
+	guessesFlag []string
+
+	guessOutput struct {
+		Word  string `json:"word"`
+		Hints string `json:"hints"`
+	}
+	suggestionOutput struct {
+		Word    string  `json:"word"`
+		Utility float64 `json:"utility"`
+		// Probability that the suggestion is the solution
+		Probability float64 `json:"probability"`
+	}
+	suggestOutput struct {
+		Guesses     []guessOutput      `json:"guesses"`
+		Entropy     float64            `json:"entropy"`
+		Candidates  []string           `json:"candidates"`
+		Strategy    string             `json:"strategy,omitempty"`
+		Suggestions []suggestionOutput `json:"suggestions"`
+	}
+	playOutput struct {
+		// Number of the puzzle, if not played with a word
+		Number  int           `json:"number,omitempty"`
+		Guesses []guessOutput `json:"guesses"`
+		Solved  bool          `json:"solved"`
+		// Only revealed once the game is over
+		Word  string `json:"word,omitempty"`
+		Share string `json:"share,omitempty"`
+	}
+)
+
+func (g *guessesFlag) String() string {
+	return strings.Join(*g, " ")
+}
+
+func (g *guessesFlag) Set(value string) error {
+	*g = append(*g, strings.ToLower(value))
+	return nil
+}
+
+// Exit statuses of failed commands, see exitStatus
+const (
+	exitFailure      = 1
+	exitInvalidGuess = 2
+	exitGameOver     = 3
+)
+
+// Runs a non-interactive command, the output is either plain text or JSON
+func runCommand(command string, args []string, dictionary *wordle.Dictionary, priors wordle.Priors, daily wordle.PuzzleSource, puzzles wordle.PuzzleSource) error {
+	switch command {
+	case "suggest":
+		return suggest(args, dictionary, priors)
+	case "play":
+		return playGuesses(args, dictionary, priors, daily, puzzles)
+	default:
+		return fmt.Errorf("Unknown command '%s', should be 'suggest' or 'play'", command)
+	}
+}
+
+// Exit status of a failed command, rejected guesses are told apart from other failures
+func exitStatus(err error) int {
+	switch {
+	case errors.Is(err, wordle.ErrGameOver):
+		return exitGameOver
+	case errors.Is(err, wordle.ErrWrongLength), errors.Is(err, wordle.ErrInvalidCharacters),
+		errors.Is(err, wordle.ErrUnknownWord), errors.Is(err, wordle.ErrHardModeViolation):
+		return exitInvalidGuess
+	default:
+		return exitFailure
+	}
+}
+
+func suggest(args []string, dictionary *wordle.Dictionary, priors wordle.Priors) error {
+	var guesses guessesFlag
+	flags := flag.NewFlagSet("suggest", flag.ExitOnError)
+	flags.Var(&guesses, "guess", "guess with hints, e.g. 'crane:gy---', 'g' is correct, 'y' is transposed and '-' is wrong, repeatable")
+	sharePath := flags.String("share", "", "file of a shared emoji grid, or '-' for stdin, the hints of which are paired with the guesses")
+	hardMode := flags.Bool("hard", false, "solve in hard mode")
+	n := flags.Int("n", 10, "number of suggestions")
+	scorerName := flags.String("scorer", "entropy", "ranks the suggestions, one of: "+strings.Join(wordle.ScorerNames(), ", "))
+	asJson := flags.Bool("json", false, "output JSON")
+	flags.Parse(args)
+
+	scorer, err := wordle.ParseScorer(*scorerName)
+	if err != nil {
+		return err
+	}
+	solver, err := wordle.NewSolver(append(commandOptions(dictionary, priors, *hardMode), wordle.WithScorer(scorer))...)
+	if err != nil {
+		return err
+	}
+
+	if *sharePath != "" {
+		grid, err := readShare(*sharePath)
+		if err != nil {
+			return err
+		}
+		if _, err := solver.AddShare(grid, guesses); err != nil {
+			return err
+		}
+	} else {
+		for _, guess := range guesses {
+			// Unlike the interactive solver, missing hints are rejected rather than grey
+			word, hintsString, _ := strings.Cut(guess, ":")
+			hints, err := wordle.ParseExactPattern(hintsString, len(word))
+			if err != nil {
+				return fmt.Errorf("guess '%s': %w", guess, err)
+			}
+			if _, err := solver.AddGuess(word, hints); err != nil {
+				return fmt.Errorf("guess '%s': %w", guess, err)
+			}
+		}
+	}
+
+	output := suggestOutput{Guesses: []guessOutput{}}
+	for _, guess := range solver.Guesses() {
+		output.Guesses = append(output.Guesses, guessOutput{guess.Word(), guess.Hints().String()})
+	}
+
+	output.Entropy = solver.Entropy()
+	output.Candidates = solver.Candidates()
+	output.Strategy, _ = solver.StrategyGuess()
+	for _, utility := range solver.Suggestions(*n) {
+		output.Suggestions = append(output.Suggestions, suggestionOutput{utility.Word, utility.Utility, utility.Probability})
+	}
+
+	if *asJson {
+		return writeJson(output)
+	}
+
+	fmt.Printf("Entropy     : %.3f bits\n", output.Entropy)
+	fmt.Printf("Candidates  : %d\n", len(output.Candidates))
+	if len(output.Candidates) <= *n {
+		fmt.Printf("              %s\n", strings.Join(output.Candidates, " "))
+	}
+	if output.Strategy != "" {
+		fmt.Printf("Strategy    : %s\n", output.Strategy)
+	}
+	fmt.Println("Suggestions :")
+	for _, suggestion := range output.Suggestions {
+		fmt.Printf("              %s  %.3f  %5.1f%%\n", suggestion.Word, suggestion.Utility, 100*suggestion.Probability)
+	}
+
+	return nil
+}
+
+func playGuesses(args []string, dictionary *wordle.Dictionary, priors wordle.Priors, daily wordle.PuzzleSource, puzzles wordle.PuzzleSource) error {
+	var guesses guessesFlag
+	flags := flag.NewFlagSet("play", flag.ExitOnError)
+	flags.Var(&guesses, "guess", "guess to play, repeatable")
+	word := flags.String("word", "", "word to guess, defaults to the next puzzle")
+	playDaily := flags.Bool("daily", false, "play the daily puzzle")
+	hardMode := flags.Bool("hard", false, "play in hard mode")
+	asJson := flags.Bool("json", false, "output JSON")
+	flags.Parse(args)
+
+	opts := commandOptions(dictionary, priors, *hardMode)
+	var game *wordle.Game
+	var err error
+	if *playDaily {
+		game, err = wordle.NewPuzzleGame(daily, opts...)
+	} else if *word != "" {
+		game, err = wordle.NewGame(strings.ToLower(*word), opts...)
+	} else {
+		game, err = wordle.NewPuzzleGame(puzzles, opts...)
+	}
+	if err != nil {
+		return err
+	}
+
+	output := playOutput{Number: game.Number(), Guesses: []guessOutput{}}
+	for _, guess := range guesses {
+		if output.Solved, err = game.Guess(guess); err != nil {
+			return fmt.Errorf("guess '%s': %w", guess, err)
+		}
+		played := game.Guesses()
+		output.Guesses = append(output.Guesses, guessOutput{guess, played[len(played)-1].Hints().String()})
+	}
+	if game.Over() {
+		output.Word = game.Word()
+		output.Share = game.Share()
+	}
+
+	if *asJson {
+		return writeJson(output)
+	}
+
+	for _, guess := range output.Guesses {
+		fmt.Printf("%s  %s\n", guess.Word, guess.Hints)
+	}
+	if output.Solved {
+		fmt.Printf("Solved in %d\n", len(output.Guesses))
+	} else if output.Word != "" {
+		fmt.Printf("The word was: %s\n", output.Word)
+	}
+	if output.Share != "" {
+		fmt.Printf("\n%s\n", output.Share)
+	}
+
+	return nil
+}
+
+// Reads a shared grid from a file, or from stdin if path is '-'
+func readShare(path string) (string, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		return string(data), err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("Unable to read shared grid '%s': %w", path, err)
+	}
+
+	return string(data), nil
+}
+
+func commandOptions(dictionary *wordle.Dictionary, priors wordle.Priors, hardMode bool) []wordle.Option {
+	opts := []wordle.Option{wordle.WithDictionary(dictionary), wordle.WithPriors(priors)}
+	if hardMode {
+		opts = append(opts, wordle.WithHardMode())
+	}
+
+	return opts
+}
+
+func writeJson(obj any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(obj)
+}
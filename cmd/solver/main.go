@@ -2,50 +2,143 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand/v2"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 
-	"github.com/Backshifted/wordle-solver/assets"
+	"github.com/Backshifted/wordle-solver/cmd/internal/cliflags"
 	"github.com/Backshifted/wordle-solver/pkg/wordle"
 )
 
 const usage = `usage:
 
-help           prints this message
-solve          run the solver
-play   [word]  starts a game, optionally pass a word`
+help                             prints this message
+solve  [--hard] [--scorer=name]  run the solver, optionally in hard mode or with a scorer
+play   [--hard] [word]           starts a game, optionally in hard mode or with a word
+       [--daily] [--absurdle]    or of the daily puzzle, or against an adversary that
+                                 avoids committing to a word
+resume [--scorer=name] file      resumes a game or solver saved with 'save [--obscure] file'
+quordle [--boards=n] [--play]    solves or plays several boards at once, 4 by default or 8 for Octordle
+        [words]
+
+Add '--guesses=n' to any command to change the number of guesses allowed, by default 6,
+or the number of boards plus 5 for 'quordle', i.e. 9 for Quordle and 13 for Octordle.
+
+Scorers rank the suggestions, one of: entropy (default), minimax, patterns, expected, guesses
+
+Run 'solver suggest -h' or 'solver play -h' for the non-interactive commands.`
 
 func main() {
+	var dictionaryFlags cliflags.DictionaryFlags
+	dictionaryFlags.Register(flag.CommandLine)
+	seed := flag.Uint64("seed", 0, "seed of the daily puzzles and, unless 0, of the random puzzles, share it to play the same puzzles")
+	puzzlesPath := flag.String("puzzles", "", "word list of the puzzles to play in order, instead of random words")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: solver [flags] [suggest|play] [command flags]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dictionary, priors, err := dictionaryFlags.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	daily := wordle.NewDailyPuzzles(dictionary.Wordles, *seed)
+	puzzles, err := newPuzzleSource(dictionary, *seed, *puzzlesPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if flag.NArg() > 0 {
+		if err := runCommand(flag.Arg(0), flag.Args()[1:], dictionary, priors, daily, puzzles); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitStatus(err))
+		}
+		return
+	}
+
 	printUsage()
 
 	scanner := bufio.NewScanner(os.Stdin)
 	for {
 		fmt.Print("> ")
-		scanner.Scan()
-		if err := scanner.Err(); err != nil {
-			log.Fatal(err)
+		segments := strings.Fields(readLine(scanner))
+		if len(segments) == 0 {
+			continue
 		}
-
-		line := strings.ToLower(scanner.Text())
-		segments := strings.Split(line, " ")
-		command := segments[0]
+		command := strings.ToLower(segments[0])
+		args, opts, err := parseOptions(segments[1:])
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		opts = append(opts, wordle.WithDictionary(dictionary), wordle.WithPriors(priors))
 
 		switch command {
 		case "help":
 			printUsage()
 		case "solve":
-			solve(scanner)
+			fmt.Println("Initializing new solver...")
+			if solver, err := wordle.NewSolver(opts...); err != nil {
+				fmt.Println(err)
+			} else {
+				solve(scanner, solver)
+			}
 		case "play":
-			if len(segments) == 1 {
-				play(scanner, "")
+			var game *wordle.Game
+			if slices.Contains(args, "--absurdle") {
+				game, err = wordle.NewAdversarialGame(opts...)
+			} else if slices.Contains(args, "--daily") {
+				game, err = wordle.NewPuzzleGame(daily, opts...)
+			} else if len(args) > 0 {
+				game, err = wordle.NewGame(strings.ToLower(args[0]), opts...)
+			} else {
+				game, err = wordle.NewPuzzleGame(puzzles, opts...)
+			}
+			if err != nil {
+				fmt.Println(err)
 			} else {
-				play(scanner, segments[1])
+				play(scanner, game)
 			}
+		case "quordle":
+			if err := quordle(scanner, puzzles, args, opts...); err != nil {
+				fmt.Println(err)
+			}
+		case "resume":
+			if len(args) != 1 {
+				fmt.Println("Usage: resume file")
+			} else if err := resume(scanner, args[0], opts...); err != nil {
+				fmt.Println(err)
+			}
+		}
+	}
+}
+
+// Plays the words of the puzzles file in order, or random solutions otherwise
+func newPuzzleSource(dictionary *wordle.Dictionary, seed uint64, puzzlesPath string) (wordle.PuzzleSource, error) {
+	if puzzlesPath == "" {
+		if seed == 0 {
+			seed = rand.Uint64()
 		}
+		return wordle.NewRandomPuzzles(dictionary.Wordles, seed), nil
+	}
+
+	data, err := os.ReadFile(puzzlesPath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read puzzles '%s': %w", puzzlesPath, err)
 	}
+	words, err := wordle.ParseWordList(data)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse puzzles '%s': %w", puzzlesPath, err)
+	}
+
+	return wordle.NewListPuzzles(words), nil
 }
 
 func printUsage() {
@@ -53,42 +146,128 @@ func printUsage() {
 	fmt.Println()
 }
 
-func solve(scanner *bufio.Scanner) {
-	fmt.Println("Initializing new solver...")
+// Reads the next line, exits once the input is exhausted
+func readLine(scanner *bufio.Scanner) string {
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	return scanner.Text()
+}
+
+// Splits the command arguments from the option flags
+func parseOptions(segments []string) (args []string, opts []wordle.Option, err error) {
+	for _, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, "--scorer="); ok {
+			scorer, err := wordle.ParseScorer(name)
+			if err != nil {
+				return nil, nil, err
+			}
+			opts = append(opts, wordle.WithScorer(scorer))
+			continue
+		}
+		if value, ok := strings.CutPrefix(segment, "--guesses="); ok {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("Invalid number of guesses '%s'", value)
+			}
+			opts = append(opts, wordle.WithMaxGuesses(n))
+			continue
+		}
+
+		switch segment {
+		case "--hard":
+			opts = append(opts, wordle.WithHardMode())
+		default:
+			args = append(args, segment)
+		}
+	}
+
+	return args, opts, nil
+}
+
+// Resumes a saved game or solver
+func resume(scanner *bufio.Scanner, path string, opts ...wordle.Option) error {
+	session, err := wordle.LoadSession(path)
+	if err != nil {
+		return err
+	}
+
+	if session.Kind == wordle.SessionGame {
+		game, err := session.ResumeGame(opts...)
+		if err != nil {
+			return err
+		}
+		play(scanner, game)
+		return nil
+	}
+
+	fmt.Println("Initializing solver...")
+	solver, err := session.ResumeSolver(opts...)
+	if err != nil {
+		return err
+	}
+	solve(scanner, solver)
+	return nil
+}
+
+// Handles the 'save [--obscure] file' command, returns false for other commands
+func save(line string, session func(obscure bool) wordle.Session) bool {
+	segments := strings.Fields(line)
+	if len(segments) == 0 || strings.ToLower(segments[0]) != "save" {
+		return false
+	}
+
+	obscure := len(segments) == 3 && segments[1] == "--obscure"
+	if len(segments) != 2 && !obscure {
+		fmt.Println("Usage: save [--obscure] file")
+	} else if err := session(obscure).Save(segments[len(segments)-1]); err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Printf("Saved to '%s', continue with 'resume %s'\n", segments[len(segments)-1], segments[len(segments)-1])
+	}
+
+	return true
+}
+
+func solve(scanner *bufio.Scanner, solver wordle.Solver) {
 	fmt.Println("Guess 'q', 'quit', or 'exit' to quit the solver")
-	solver := wordle.NewSolver()
+	fmt.Println("Guess 'undo' to remove the last guess, or 'back n' to keep only the first n guesses")
+	fmt.Println("Guess 'save file' to save the guesses, and continue later with 'resume file'")
 	fmt.Println()
 	fmt.Println(solver)
 	fmt.Println()
 
 	for {
 		fmt.Print("Guess       : ")
-		scanner.Scan()
-		if err := scanner.Err(); err != nil {
-			log.Fatal(err)
-		}
-		word := strings.ToLower(scanner.Text())
+		line := readLine(scanner)
+		word := strings.ToLower(line)
 
 		if word == "quit" || word == "exit" || word == "q" {
 			return
 		}
-
-		fmt.Print("Hint (g/y/ ): ")
-		scanner.Scan()
-		if err := scanner.Err(); err != nil {
-			log.Fatal(err)
+		if save(line, func(bool) wordle.Session { return solver.Session() }) {
+			continue
 		}
-		hintsString := scanner.Text()
-
-		hints := [5]wordle.Hint{}
-		for i := range word {
-			if i < len(hintsString) && hintsString[i] == 'g' {
-				hints[i] = wordle.LetterCorrect
-			} else if i < len(hintsString) && hintsString[i] == 'y' {
-				hints[i] = wordle.LetterTransposed
+		if command, arg, _ := strings.Cut(word, " "); command == "undo" || command == "back" {
+			if err := rewind(&solver, command, arg); err != nil {
+				fmt.Println(err)
 			} else {
-				hints[i] = wordle.LetterWrong
+				fmt.Println()
+				fmt.Println(solver)
+				fmt.Println()
 			}
+			continue
+		}
+
+		fmt.Print("Hint (g/y/-): ")
+		hints, err := wordle.ParsePattern(readLine(scanner), len(word))
+		if err != nil {
+			fmt.Println(err)
+			continue
 		}
 
 		if done, err := solver.AddGuess(word, hints); err != nil {
@@ -97,6 +276,7 @@ func solve(scanner *bufio.Scanner) {
 			fmt.Println()
 			fmt.Println(solver)
 			fmt.Println()
+			fmt.Printf("%s\n\n", solver.Share(0))
 			break
 		} else {
 			fmt.Println()
@@ -108,36 +288,50 @@ func solve(scanner *bufio.Scanner) {
 	fmt.Println("Solver finished!")
 }
 
-func play(scanner *bufio.Scanner, word string) {
-	if word == "" {
-		word = assets.Wordles[rand.Int()%len(assets.Wordles)]
+// Handles the 'undo' and 'back n' commands of the solver
+func rewind(solver *wordle.Solver, command string, arg string) error {
+	if command == "undo" {
+		return solver.Undo()
 	}
-	game := wordle.NewGame(word)
+
+	n, err := strconv.Atoi(strings.TrimSpace(arg))
+	if err != nil {
+		return fmt.Errorf("Usage: back n, where n is the number of guesses to keep")
+	}
+
+	return solver.Rewind(n)
+}
+
+func play(scanner *bufio.Scanner, game *wordle.Game) {
+	fmt.Println("Guess 'save [--obscure] file' to save the game, and continue later with 'resume file'")
 	fmt.Println(game)
 
-	for numGuesses := 0; numGuesses < wordle.MaxGuesses; {
+	for !game.Over() {
 		fmt.Print("Guess: ")
-		scanner.Scan()
-		if err := scanner.Err(); err != nil {
-			log.Fatal(err)
-		}
-		guess := strings.ToLower(scanner.Text())
+		line := readLine(scanner)
+		guess := strings.ToLower(line)
 
 		if guess == "quit" || guess == "exit" || guess == "q" {
 			break
 		}
+		if save(line, game.Session) {
+			continue
+		}
 
 		if done, err := game.Guess(guess); err != nil {
 			fmt.Println(err)
 		} else if done {
 			fmt.Println(game)
 			fmt.Println("You won!")
+			fmt.Printf("\n%s\n\n", game.Share())
 			return
 		} else {
-			numGuesses++
 			fmt.Println(game)
 		}
 	}
 
-	fmt.Printf("The word was: %s\n\n", word)
+	fmt.Printf("The word was: %s\n\n", game.Word())
+	if game.Over() {
+		fmt.Printf("%s\n\n", game.Share())
+	}
 }
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Backshifted/wordle-solver/pkg/wordle"
+)
+
+const defaultBoards = 4
+
+// Handles the 'quordle' command, args are '--boards=n', '--play' and the words to play
+func quordle(scanner *bufio.Scanner, puzzles wordle.PuzzleSource, args []string, opts ...wordle.Option) error {
+	numBoards := defaultBoards
+	playing := false
+	var words []string
+	for _, arg := range args {
+		if value, ok := strings.CutPrefix(arg, "--boards="); ok {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("Invalid number of boards '%s'", value)
+			}
+			numBoards = n
+		} else if arg == "--play" {
+			playing = true
+		} else {
+			words = append(words, strings.ToLower(arg))
+		}
+	}
+
+	if !playing {
+		fmt.Println("Initializing new solver...")
+		solver, err := wordle.NewMultiSolver(numBoards, opts...)
+		if err != nil {
+			return err
+		}
+		solveBoards(scanner, solver)
+		return nil
+	}
+
+	if len(words) == 0 {
+		for range numBoards {
+			puzzle, err := puzzles.Next()
+			if err != nil {
+				return err
+			}
+			words = append(words, puzzle.Word)
+		}
+	}
+	game, err := wordle.NewMultiGame(words, opts...)
+	if err != nil {
+		return err
+	}
+	playBoards(scanner, game)
+	return nil
+}
+
+func solveBoards(scanner *bufio.Scanner, solver *wordle.MultiSolver) {
+	fmt.Println("Guess 'q', 'quit', or 'exit' to quit the solver, or 'undo' to remove the last guess")
+	fmt.Println("Hints are asked for each unsolved board")
+	printBoards(solver)
+
+	for !solver.Done() {
+		fmt.Print("Guess         : ")
+		word := strings.ToLower(readLine(scanner))
+
+		if word == "quit" || word == "exit" || word == "q" {
+			return
+		}
+		if word == "undo" {
+			if err := solver.Undo(); err != nil {
+				fmt.Println(err)
+			} else {
+				printBoards(solver)
+			}
+			continue
+		}
+
+		hints, err := readBoardHints(scanner, solver.Solved(), len(word))
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		if _, err := solver.AddGuess(word, hints); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		printBoards(solver)
+	}
+
+	fmt.Println("Solver finished!")
+}
+
+// Reads the hints of each unsolved board, those of solved boards are left nil
+func readBoardHints(scanner *bufio.Scanner, solved []bool, wordLength int) ([]wordle.Pattern, error) {
+	hints := make([]wordle.Pattern, len(solved))
+	for i := range hints {
+		if solved[i] {
+			continue
+		}
+
+		fmt.Printf("Hint %d (g/y/-): ", i+1)
+		var err error
+		if hints[i], err = wordle.ParsePattern(readLine(scanner), wordLength); err != nil {
+			return nil, fmt.Errorf("Board %d: %w", i+1, err)
+		}
+	}
+
+	return hints, nil
+}
+
+func printBoards(solver *wordle.MultiSolver) {
+	fmt.Println()
+	fmt.Println(solver)
+	if !solver.Done() {
+		fmt.Println("Suggestions:")
+		for _, suggestion := range solver.Suggestions(5) {
+			fmt.Printf("  %s  %.3f  %.2f boards\n", suggestion.Word, suggestion.Utility, suggestion.ExpectedSolved)
+		}
+	}
+	fmt.Println()
+}
+
+func playBoards(scanner *bufio.Scanner, game *wordle.MultiGame) {
+	fmt.Println(game)
+
+	for !game.Over() {
+		fmt.Print("Guess: ")
+		guess := strings.ToLower(readLine(scanner))
+
+		if guess == "quit" || guess == "exit" || guess == "q" {
+			break
+		}
+
+		if _, err := game.Guess(guess); err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Println(game)
+		}
+	}
+
+	if game.Solved() {
+		fmt.Printf("You won in %d guesses!\n\n", len(game.Guesses()))
+	} else {
+		fmt.Printf("The words were: %s\n\n", strings.Join(game.Words(), " "))
+	}
+}
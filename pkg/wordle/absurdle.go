@@ -0,0 +1,61 @@
+package wordle
+
+import "slices"
+
+// Creates an adversarial game, as in Absurdle, that does not commit to a word. Each guess
+// is given the hints that keep the most solutions of the dictionary possible, so the
+// game is only won once a single solution remains and it is guessed.
+func NewAdversarialGame(opts ...Option) (*Game, error) {
+	state, err := NewGameState(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Game{
+		state:     state,
+		remaining: state.dictionary.solutionTree,
+	}, nil
+}
+
+// Whether the game picks its hints adversarially, see NewAdversarialGame
+func (g *Game) Adversarial() bool {
+	return g.remaining != nil
+}
+
+func (g *Game) guessAdversarial(word string) (bool, error) {
+	// Finding the hints is expensive, reject unknown words first
+	if err := g.state.dictionary.CheckWord(word); err != nil {
+		return false, err
+	}
+
+	hints, constraint := g.adversarialHints(word)
+	if err := g.state.AddGuess(word, hints); err != nil {
+		return false, err
+	}
+
+	g.remaining = NewWordleTree(g.remaining.WordLength, g.remaining.Wordles, constraint)
+	if hints.Solved() {
+		g.word = word
+	}
+	return hints.Solved(), nil
+}
+
+// The hints of the guess that leave the largest set of remaining solutions, together with
+// their constraint. Ties go to the lowest pattern index, i.e. the one revealing the least.
+func (g *Game) adversarialHints(word string) (Pattern, Constraint) {
+	var best Pattern
+	var bestConstraint Constraint
+	bestCount := -1
+	for _, pattern := range AllPatterns(len(word)) {
+		if checkPattern(word, pattern) != nil {
+			continue
+		}
+
+		constraint := constraintFromPattern(word, pattern)
+		if count := g.remaining.CountMatches(constraint); count > bestCount {
+			best, bestConstraint, bestCount = pattern, constraint, count
+		}
+	}
+
+	return slices.Clone(best), bestConstraint
+}
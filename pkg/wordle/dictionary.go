@@ -0,0 +1,332 @@
+package wordle
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Backshifted/wordle-solver/assets"
+)
+
+// Number of words suggested for an unknown word
+const maxSuggestions = 5
+
+// Solutions and additional guesses of a single word length
+type Dictionary struct {
+	WordLength int
+	Wordles    []string
+	NonWordles []string
+
+	solutionTree *WordleTree
+	guessTree    *WordleTree
+	// Computing the pattern matrix is expensive, it is deferred
+	// until a solver needs it unless it is loaded from a file.
+	patternMatrix     *PatternMatrix
+	patternMatrixOnce sync.Once
+	// Optional precomputed strategy
+	strategy *Strategy
+}
+
+var (
+	defaultDictionary     *Dictionary
+	defaultDictionaryErr  error
+	defaultDictionaryOnce sync.Once
+)
+
+// Loads the embedded Wordle dictionary, it is decoded once on first use and
+// shared afterwards. Games and solvers use it unless WithDictionary is passed.
+func LoadDefaultDictionary() (*Dictionary, error) {
+	defaultDictionaryOnce.Do(func() {
+		defaultDictionary, defaultDictionaryErr = loadDefaultDictionary()
+	})
+
+	return defaultDictionary, defaultDictionaryErr
+}
+
+func loadDefaultDictionary() (*Dictionary, error) {
+	wordles, err := assets.LoadJsonStringArray(assets.WordlesFile)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load wordles: %w", err)
+	}
+	nonWordles, err := assets.LoadJsonStringArray(assets.NonwordlesFile)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load non-wordles: %w", err)
+	}
+
+	d := &Dictionary{
+		WordLength: DefaultWordLength,
+		Wordles:    wordles,
+		NonWordles: nonWordles,
+	}
+
+	// Fall back to building the trees if they have not been precomputed
+	if d.solutionTree, err = loadPrecomputed[*WordleTree]("solution-tree", d.WordLength); err != nil {
+		return nil, err
+	} else if d.solutionTree == nil {
+		d.solutionTree = NewWordleTree(d.WordLength, wordles, NewConstraint())
+	}
+	if d.guessTree, err = loadPrecomputed[*WordleTree]("guess-tree", d.WordLength); err != nil {
+		return nil, err
+	} else if d.guessTree == nil {
+		d.guessTree = NewWordleTree(d.WordLength, append(slices.Clone(wordles), nonWordles...), NewConstraint())
+	}
+
+	// Outdated strategies are ignored, like missing ones
+	if d.strategy, err = loadPrecomputed[*Strategy]("strategy", d.WordLength); err != nil {
+		return nil, err
+	} else if d.strategy != nil && d.strategy.Version != StrategyVersion {
+		d.strategy = nil
+	}
+
+	return d, nil
+}
+
+// Decodes a precomputed asset, returns the zero value if it has not been precomputed
+func loadPrecomputed[T any](name string, wordLength int) (obj T, err error) {
+	data, err := assets.ReadPrecomputed(name, wordLength)
+	if errors.Is(err, fs.ErrNotExist) {
+		return obj, nil
+	} else if err != nil {
+		return obj, err
+	}
+
+	if obj, err = assets.Load[T](data); err != nil {
+		return obj, fmt.Errorf("Unable to load '%s': %w", assets.PrecomputedName(name, wordLength), err)
+	}
+
+	return obj, nil
+}
+
+// Creates a dictionary from solutions and additional guesses, all words must be
+// lowercase ASCII of the same length between MinWordLength and MaxWordLength.
+func NewDictionary(wordles []string, nonWordles []string) (*Dictionary, error) {
+	if len(wordles) == 0 {
+		return nil, fmt.Errorf("Dictionary has no wordles")
+	}
+
+	wordLength := len(wordles[0])
+	if wordLength < MinWordLength || wordLength > MaxWordLength {
+		return nil, fmt.Errorf("Invalid word length %d, should be between %d and %d", wordLength, MinWordLength, MaxWordLength)
+	}
+
+	seen := make(map[string]bool, len(wordles)+len(nonWordles))
+	uniqueWords := func(words []string) ([]string, error) {
+		unique := make([]string, 0, len(words))
+		for _, word := range words {
+			if len(word) != wordLength {
+				return nil, fmt.Errorf("Invalid length of word '%s', should be %d", word, wordLength)
+			}
+			if !isAscii(word) {
+				return nil, fmt.Errorf("Invalid characters in word '%s', should be a-z", word)
+			}
+			if !seen[word] {
+				seen[word] = true
+				unique = append(unique, word)
+			}
+		}
+		return unique, nil
+	}
+
+	var err error
+	if wordles, err = uniqueWords(wordles); err != nil {
+		return nil, err
+	}
+	if nonWordles, err = uniqueWords(nonWordles); err != nil {
+		return nil, err
+	}
+
+	d := &Dictionary{
+		WordLength: wordLength,
+		Wordles:    wordles,
+		NonWordles: nonWordles,
+	}
+	d.solutionTree = NewWordleTree(wordLength, wordles, NewConstraint())
+	d.guessTree = NewWordleTree(wordLength, append(slices.Clone(wordles), nonWordles...), NewConstraint())
+	return d, nil
+}
+
+// Loads a dictionary from word list files, see ParseWordList for the supported formats.
+// Without a path of the solutions the Wordle solutions are used, and without either
+// path the default dictionary. The path of the additional guesses may be empty.
+func LoadDictionary(wordlesPath string, nonWordlesPath string) (*Dictionary, error) {
+	if wordlesPath == "" && nonWordlesPath == "" {
+		return LoadDefaultDictionary()
+	}
+
+	var wordles []string
+	var err error
+	if wordlesPath == "" {
+		wordles, err = assets.LoadJsonStringArray(assets.WordlesFile)
+	} else {
+		wordles, err = readWordList(wordlesPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var nonWordles []string
+	if nonWordlesPath != "" {
+		if nonWordles, err = readWordList(nonWordlesPath); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewDictionary(wordles, nonWordles)
+}
+
+func readWordList(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read word list '%s': %w", path, err)
+	}
+
+	words, err := ParseWordList(data)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse word list '%s': %w", path, err)
+	}
+
+	return words, nil
+}
+
+// Parses either a JSON array of strings, or plain text with a word per line.
+// Blank lines and lines starting with '#' are skipped, words are lowercased.
+func ParseWordList(data []byte) ([]string, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		words, err := assets.LoadJsonStringArray(trimmed)
+		if err != nil {
+			return nil, err
+		}
+		for i, word := range words {
+			words[i] = strings.ToLower(strings.TrimSpace(word))
+		}
+		return words, nil
+	}
+
+	var words []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, strings.ToLower(line))
+	}
+
+	return words, scanner.Err()
+}
+
+// Reports whether word is an allowed guess
+func (d *Dictionary) IsValid(word string) bool {
+	return d.guessTree.Contains(word)
+}
+
+// Reports whether word is one of the solutions
+func (d *Dictionary) IsSolution(word string) bool {
+	return d.solutionTree.Contains(word)
+}
+
+// Checks that word is an allowed guess, see WordError. Unknown words and words
+// of the wrong length come with the allowed guesses they might have meant.
+func (d *Dictionary) CheckWord(word string) error {
+	if word != "" && !isAscii(word) {
+		return &WordError{
+			Word:    word,
+			err:     ErrInvalidCharacters,
+			message: fmt.Sprintf("Invalid characters in word '%s', should be a-z", word),
+		}
+	}
+	if len(word) != d.WordLength {
+		return &WordError{
+			Word:        word,
+			Suggestions: d.Suggest(word),
+			err:         ErrWrongLength,
+			message:     fmt.Sprintf("Invalid word length %d, should be %d", len(word), d.WordLength),
+		}
+	}
+	if !d.IsValid(word) {
+		return &WordError{
+			Word:        word,
+			Suggestions: d.Suggest(word),
+			err:         ErrUnknownWord,
+			message:     fmt.Sprintf("Unknown word '%s'", word),
+		}
+	}
+
+	return nil
+}
+
+// Allowed guesses within an edit distance of 1 of word, i.e. with a letter replaced,
+// inserted or removed. Solutions come first, at most maxSuggestions are returned.
+func (d *Dictionary) Suggest(word string) []string {
+	var candidates []string
+	switch len(word) {
+	case d.WordLength:
+		for i := range len(word) {
+			for char := byte('a'); char <= 'z'; char++ {
+				if char != word[i] {
+					candidates = append(candidates, word[:i]+string(char)+word[i+1:])
+				}
+			}
+		}
+	case d.WordLength - 1:
+		for i := range len(word) + 1 {
+			for char := byte('a'); char <= 'z'; char++ {
+				candidates = append(candidates, word[:i]+string(char)+word[i:])
+			}
+		}
+	case d.WordLength + 1:
+		for i := range len(word) {
+			candidates = append(candidates, word[:i]+word[i+1:])
+		}
+	}
+
+	var solutions, guesses []string
+	for _, candidate := range candidates {
+		if d.IsSolution(candidate) {
+			solutions = append(solutions, candidate)
+		} else if d.IsValid(candidate) {
+			guesses = append(guesses, candidate)
+		}
+	}
+	slices.Sort(solutions)
+	slices.Sort(guesses)
+	suggestions := slices.Compact(append(solutions, guesses...))
+
+	return suggestions[:min(maxSuggestions, len(suggestions))]
+}
+
+// Loads a pattern matrix written by the precompute command, solvers of the dictionary
+// use it instead of computing the matrix. It must match the words of the dictionary.
+func (d *Dictionary) LoadPatternMatrix(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Unable to read pattern matrix '%s': %w", path, err)
+	}
+	m, err := assets.Load[*PatternMatrix](data)
+	if err != nil {
+		return fmt.Errorf("Unable to load pattern matrix '%s': %w", path, err)
+	}
+	if !m.complete() || !slices.Equal(m.Guesses, d.guessTree.Wordles) || !slices.Equal(m.Solutions, d.solutionTree.Wordles) {
+		return fmt.Errorf("Pattern matrix '%s' does not match the dictionary, it should be precomputed again", path)
+	}
+
+	m.buildIndex()
+	d.patternMatrixOnce.Do(func() {
+		d.patternMatrix = m
+	})
+	return nil
+}
+
+func (d *Dictionary) getPatternMatrix() *PatternMatrix {
+	d.patternMatrixOnce.Do(func() {
+		d.patternMatrix = NewPatternMatrix(d.WordLength, d.guessTree.Wordles, d.solutionTree.Wordles)
+	})
+
+	return d.patternMatrix
+}
@@ -0,0 +1,48 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors of rejected guesses, check them with errors.Is
+var (
+	ErrGameOver          = errors.New("Game is over")
+	ErrWrongLength       = errors.New("Invalid length")
+	ErrInvalidCharacters = errors.New("Invalid characters")
+	ErrUnknownWord       = errors.New("Unknown word")
+	ErrHardModeViolation = errors.New("Guess violates hard mode")
+)
+
+// Returned for words that are not allowed guesses, it wraps ErrWrongLength,
+// ErrInvalidCharacters or ErrUnknownWord.
+type WordError struct {
+	Word string
+	// Allowed guesses within one letter of the word, see Dictionary.Suggest
+	Suggestions []string
+
+	err     error
+	message string
+}
+
+func (e *WordError) Error() string {
+	if len(e.Suggestions) > 0 {
+		return fmt.Sprintf("%s, did you mean: %s?", e.message, strings.Join(e.Suggestions, ", "))
+	}
+
+	return e.message
+}
+
+func (e *WordError) Unwrap() error {
+	return e.err
+}
+
+// Hard mode errors match ErrHardModeViolation
+func (e *HardModeError) Is(target error) bool {
+	return target == ErrHardModeViolation
+}
+
+func newGameOverError(maxGuesses int) error {
+	return fmt.Errorf("%w, exceeded maximum number of guesses: %d", ErrGameOver, maxGuesses)
+}
@@ -4,13 +4,13 @@ import (
 	"fmt"
 	"slices"
 	"strings"
-
-	"github.com/Backshifted/wordle-solver/assets"
 )
 
 const (
 	MaxGuesses           = 6
-	WordLength           = 5
+	MinWordLength        = 4
+	MaxWordLength        = 8
+	DefaultWordLength    = 5
 	qwertyKeyboardLayout = "q w e r t y u i o p\n a s d f g h j k l\n  z x c v b n m"
 
 	ansiReset          = "\033[0m"
@@ -34,11 +34,20 @@ type (
 	Hint  byte
 	Guess struct {
 		word  string
-		hints [5]Hint
+		hints Pattern
 	}
 	GameState struct {
-		guesses  [6]*Guess
-		alphabet [26]Hint
+		guesses    []*Guess
+		maxGuesses int
+		alphabet   [26]Hint
+		hardMode   bool
+		dictionary *Dictionary
+	}
+	// Returned when a guess ignores a revealed hint in hard mode
+	HardModeError struct {
+		Letter byte
+		// Position of a correct letter, -1 for a transposed letter
+		Position int
 	}
 )
 
@@ -49,54 +58,161 @@ const (
 	LetterCorrect
 )
 
-func NewGuess(word string, hints [5]Hint) *Guess {
+func NewGuess(word string, hints Pattern) *Guess {
 	return &Guess{
 		word:  word,
 		hints: hints,
 	}
 }
 
-func NewGameState() GameState {
+func (g *Guess) Word() string {
+	return g.word
+}
+
+func (g *Guess) Hints() Pattern {
+	return slices.Clone(g.hints)
+}
+
+func NewGameState(opts ...Option) (GameState, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return GameState{}, err
+	}
+
 	return GameState{
-		guesses:  [6]*Guess{},
-		alphabet: [26]Hint{},
+		maxGuesses: o.maxGuesses,
+		alphabet:   [26]Hint{},
+		hardMode:   o.hardMode,
+		dictionary: o.dictionary,
+	}, nil
+}
+
+// The guesses made so far
+func (gs *GameState) Guesses() []*Guess {
+	return slices.Clone(gs.guesses)
+}
+
+// The number of guesses allowed, see WithMaxGuesses
+func (gs *GameState) MaxGuesses() int {
+	return gs.maxGuesses
+}
+
+func (e *HardModeError) Error() string {
+	if e.Position >= 0 {
+		return fmt.Sprintf("%s letter must be %c", ordinal(e.Position+1), e.Letter-32)
+	}
+	return fmt.Sprintf("Guess must contain %c", e.Letter-32)
+}
+
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", n)
 	}
 }
 
-func (gs *GameState) AddGuess(word string, hints [5]Hint) error {
-	if gs.guesses[MaxGuesses-1] != nil {
-		return fmt.Errorf("Exceeded maximum number of guesses: %d", MaxGuesses)
+func (gs *GameState) AddGuess(word string, hints Pattern) error {
+	if len(gs.guesses) >= gs.maxGuesses {
+		return newGameOverError(gs.maxGuesses)
 	}
-	if len(word) != 5 {
-		return fmt.Errorf("Invalid word length %d, should be %d", len(word), WordLength)
+	if err := gs.dictionary.CheckWord(word); err != nil {
+		return err
 	}
-	if len(hints) != 5 {
-		return fmt.Errorf("Invalid hints length %d, should be %d", len(hints), WordLength)
+	if len(hints) != gs.dictionary.WordLength {
+		return fmt.Errorf("%w of hints %d, should be %d", ErrWrongLength, len(hints), gs.dictionary.WordLength)
 	}
-	if !isAscii(word) {
-		return fmt.Errorf("Exceeded maximum number of guesses: %d", MaxGuesses)
+	for i, hint := range hints {
+		if hint < LetterWrong || hint > LetterCorrect {
+			return fmt.Errorf("%w: hint %d at %s letter, should be wrong, transposed or correct", ErrInvalidCharacters, hint, ordinal(i+1))
+		}
 	}
-	if !slices.Contains(assets.Wordles, word) && !slices.Contains(assets.NonWordles, word) {
-		return fmt.Errorf("Not a valid word")
+	if gs.hardMode {
+		if err := gs.checkHardMode(word); err != nil {
+			return err
+		}
 	}
 
-	for i, guess := range gs.guesses {
-		if guess == nil {
-			// Update alphabet
-			for j, hint := range hints {
-				letterIndex := word[j] - 'a'
-				// Make use of ordered enum
-				gs.alphabet[letterIndex] = max(hint, gs.alphabet[letterIndex])
-			}
+	gs.updateAlphabet(word, hints)
+	// Copies of the state share the guesses, clipping prevents overwriting theirs
+	gs.guesses = append(slices.Clip(gs.guesses), NewGuess(word, slices.Clone(hints)))
 
-			gs.guesses[i] = NewGuess(word, [5]Hint(hints))
-			break
+	return nil
+}
+
+// Whether the last guess is all correct
+func (gs *GameState) solved() bool {
+	return len(gs.guesses) > 0 && gs.guesses[len(gs.guesses)-1].hints.Solved()
+}
+
+// Removes the last guess
+func (gs *GameState) Undo() error {
+	numGuesses := len(gs.Guesses())
+	if numGuesses == 0 {
+		return fmt.Errorf("No guesses to undo")
+	}
+
+	return gs.Rewind(numGuesses - 1)
+}
+
+// Removes all guesses after the first n
+func (gs *GameState) Rewind(n int) error {
+	if n < 0 || n > len(gs.guesses) {
+		return fmt.Errorf("Invalid number of guesses %d, should be between 0 and %d", n, len(gs.guesses))
+	}
+
+	gs.guesses = gs.guesses[:n]
+	gs.alphabet = [26]Hint{}
+	for _, guess := range gs.guesses {
+		gs.updateAlphabet(guess.word, guess.hints)
+	}
+
+	return nil
+}
+
+func (gs *GameState) updateAlphabet(word string, hints Pattern) {
+	for i, hint := range hints {
+		letterIndex := word[i] - 'a'
+		// Make use of ordered enum
+		gs.alphabet[letterIndex] = max(hint, gs.alphabet[letterIndex])
+	}
+}
+
+// Checks that word keeps all greens in place and includes all yellows
+func (gs *GameState) checkHardMode(word string) error {
+	for _, guess := range gs.guesses {
+		for i, hint := range guess.hints {
+			if hint == LetterCorrect && word[i] != guess.word[i] {
+				return &HardModeError{Letter: guess.word[i], Position: i}
+			}
+		}
+		for i, hint := range guess.hints {
+			char := guess.word[i]
+			if hint == LetterTransposed && strings.Count(word, string(char)) < guess.revealedCount(char) {
+				return &HardModeError{Letter: char, Position: -1}
+			}
 		}
 	}
 
 	return nil
 }
 
+// Number of correct and transposed occurrences of char
+func (g *Guess) revealedCount(char byte) (count int) {
+	for i, hint := range g.hints {
+		if g.word[i] == char && hint != LetterWrong {
+			count++
+		}
+	}
+
+	return count
+}
+
 func isAscii(s string) bool {
 	for _, r := range s {
 		if r < 'a' || r > 'z' {
@@ -108,18 +224,18 @@ func isAscii(s string) bool {
 
 func (gs GameState) String() string {
 	const prefix = "      "
+	border := prefix + "+" + strings.Repeat("-", gs.dictionary.WordLength) + "+\n"
 	output := strings.Builder{}
-	output.WriteString(prefix + "+-----+\n")
+	output.WriteString(border)
 
-	var i int
-	for i = 0; i < len(gs.guesses) && gs.guesses[i] != nil; i++ {
-		output.WriteString(prefix + "|" + formatGuess(gs.guesses[i]) + "|\n")
+	for _, guess := range gs.guesses {
+		output.WriteString(prefix + "|" + formatGuess(guess) + "|\n")
 	}
-	for ; i < MaxGuesses; i++ {
-		output.WriteString(prefix + "|     |\n")
+	for range gs.maxGuesses - len(gs.guesses) {
+		output.WriteString(prefix + "|" + strings.Repeat(" ", gs.dictionary.WordLength) + "|\n")
 	}
 
-	output.WriteString(prefix + "+-----+\n")
+	output.WriteString(border)
 	output.WriteString(formatAlphabet(gs.alphabet[:]))
 	return output.String()
 }
@@ -127,7 +243,7 @@ func (gs GameState) String() string {
 func formatGuess(guess *Guess) string {
 	output := strings.Builder{}
 
-	for i := range WordLength {
+	for i := range len(guess.word) {
 		formatLetter(&output, guess.word[i], guess.hints[i])
 	}
 
@@ -164,56 +280,80 @@ func formatLetter(b *strings.Builder, letter byte, hint Hint) {
 type Game struct {
 	word  string
 	state GameState
+	// Remaining solutions of an adversarial game, nil if the word is fixed
+	remaining *WordleTree
+	// Number of the puzzle, see NewPuzzleGame
+	number int
 }
 
-func NewGame(word string) *Game {
+// Creates a game of the word, which must be an allowed guess of the dictionary
+func NewGame(word string, opts ...Option) (*Game, error) {
+	state, err := NewGameState(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := state.dictionary.CheckWord(word); err != nil {
+		return nil, fmt.Errorf("Invalid word to guess: %w", err)
+	}
+
 	return &Game{
 		word:  word,
-		state: NewGameState(),
-	}
+		state: state,
+	}, nil
 }
 
+// Guesses the word, returns whether it is the hidden word. Rejected guesses return
+// one of the errors of this package, e.g. ErrGameOver once the game is over.
 func (g *Game) Guess(word string) (bool, error) {
-	if len(word) != 5 {
-		return false, fmt.Errorf("Invalid word length %d, should be %d", len(word), WordLength)
+	if g.Solved() {
+		return false, fmt.Errorf("%w, the word has been guessed", ErrGameOver)
+	} else if len(g.state.guesses) >= g.state.maxGuesses {
+		return false, newGameOverError(g.state.maxGuesses)
 	}
-
-	hints := [5]Hint{}
-	for i := range WordLength {
-		hints[i] = LetterWrong
-	}
-	bag := []byte(g.word)
-
-	// Check correct letter
-	for i := range WordLength {
-		if word[i] == bag[i] {
-			// Prevent duplicate yellows by removing letters from the word/bag
-			bag[i] = '?'
-			hints[i] = LetterCorrect
-		}
+	if g.remaining != nil {
+		return g.guessAdversarial(word)
 	}
-	// Check transposed letters
-	for i := range WordLength {
-		if index := slices.Index(bag, word[i]); index != -1 {
-			// Prevent duplicate yellows by removing letters from the word/bag
-			bag[index] = '?'
-			hints[i] = LetterTransposed
-		}
+	if len(word) != len(g.word) {
+		return false, g.state.dictionary.CheckWord(word)
 	}
 
+	hints := Score(word, g.word)
 	if err := g.state.AddGuess(word, hints); err != nil {
 		return false, err
 	}
 
-	for _, hint := range hints {
-		if hint != LetterCorrect {
-			return false, nil
-		}
+	return hints.Solved(), nil
+}
+
+// The hidden word, to reveal once the game is over. Adversarial games only
+// commit to a word once it is guessed, until then one of the remaining is returned.
+func (g *Game) Word() string {
+	if g.word == "" && g.remaining != nil && g.remaining.WordCount > 0 {
+		return g.remaining.Wordles[0]
 	}
 
-	return true, nil
+	return g.word
+}
+
+// The guesses made so far
+func (g *Game) Guesses() []*Guess {
+	return g.state.Guesses()
+}
+
+// Whether the word has been guessed
+func (g *Game) Solved() bool {
+	return g.state.solved()
+}
+
+// Whether the word has been guessed or the guesses have run out
+func (g *Game) Over() bool {
+	return g.Solved() || len(g.state.guesses) >= g.state.maxGuesses
 }
 
 func (g Game) String() string {
+	if g.number > 0 {
+		return fmt.Sprintf("      Wordle %s\n%s", formatThousands(g.number), g.state.String())
+	}
+
 	return g.state.String()
 }
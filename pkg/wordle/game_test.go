@@ -0,0 +1,29 @@
+package wordle
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGameOverBeforeWordErrors(t *testing.T) {
+	game, err := NewGame("abide", WithMaxGuesses(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	adversarial, err := NewAdversarialGame(WithMaxGuesses(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, g := range []*Game{game, adversarial} {
+		if _, err := g.Guess("crane"); err != nil {
+			t.Fatal(err)
+		}
+		// Words of another length, invalid or unknown words are no longer checked
+		for _, word := range []string{"spéed", "cranes", "zzzzz", "crane"} {
+			if _, err := g.Guess(word); !errors.Is(err, ErrGameOver) {
+				t.Errorf("Guess(%s) after the last guess: expected %v, got %v", word, ErrGameOver, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package wordle
+
+import (
+	"runtime"
+	"sync"
+)
+
+// The pattern index of every pair of guess and solution, scoring a guess only
+// takes counting the indexes in its row. Indexes are uint8 as long as the patterns
+// fit, words of 6 letters or more have more than 256 patterns and use WideIndexes.
+type PatternMatrix struct {
+	WordLength int
+	Guesses    []string
+	Solutions  []string
+	// Row-major, the row of a guess holds an index per solution. Only one is set.
+	Indexes     []uint8
+	WideIndexes []uint16
+
+	guessRows       map[string]int
+	solutionColumns map[string]int
+}
+
+// A row of the pattern matrix, see PatternMatrix.Row
+type MatrixRow struct {
+	indexes     []uint8
+	wideIndexes []uint16
+}
+
+func NewPatternMatrix(wordLength int, guesses []string, solutions []string) *PatternMatrix {
+	m := &PatternMatrix{
+		WordLength: wordLength,
+		Guesses:    guesses,
+		Solutions:  solutions,
+	}
+	if NumPatterns(wordLength) <= 256 {
+		m.Indexes = make([]uint8, len(guesses)*len(solutions))
+	} else {
+		m.WideIndexes = make([]uint16, len(guesses)*len(solutions))
+	}
+
+	numWorkers := runtime.GOMAXPROCS(0)
+	var wg sync.WaitGroup
+	for worker := range numWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hints := make(Pattern, wordLength)
+			for i := worker; i < len(guesses); i += numWorkers {
+				row := m.Row(i)
+				for j, solution := range solutions {
+					scoreInto(guesses[i], solution, hints)
+					row.set(j, patternIndex(hints))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	m.buildIndex()
+	return m
+}
+
+// Whether the indexes cover every guess and solution, e.g. of a decoded matrix
+func (m *PatternMatrix) complete() bool {
+	size := len(m.Guesses) * len(m.Solutions)
+	if NumPatterns(m.WordLength) <= 256 {
+		return len(m.Indexes) == size
+	}
+	return len(m.WideIndexes) == size
+}
+
+// Maps the words to their rows and columns, these are not encoded
+func (m *PatternMatrix) buildIndex() {
+	m.guessRows = make(map[string]int, len(m.Guesses))
+	for i, guess := range m.Guesses {
+		m.guessRows[guess] = i
+	}
+	m.solutionColumns = make(map[string]int, len(m.Solutions))
+	for i, solution := range m.Solutions {
+		m.solutionColumns[solution] = i
+	}
+}
+
+// The pattern indexes of the guess at index i for every solution
+func (m *PatternMatrix) Row(i int) MatrixRow {
+	start, end := i*len(m.Solutions), (i+1)*len(m.Solutions)
+	if m.WideIndexes != nil {
+		return MatrixRow{wideIndexes: m.WideIndexes[start:end]}
+	}
+	return MatrixRow{indexes: m.Indexes[start:end]}
+}
+
+// The row of the guess, false if it is not one of the guesses
+func (m *PatternMatrix) GuessRow(guess string) (MatrixRow, bool) {
+	i, ok := m.guessRows[guess]
+	if !ok {
+		return MatrixRow{}, false
+	}
+
+	return m.Row(i), true
+}
+
+// The column of the solution, false if it is not one of the solutions
+func (m *PatternMatrix) column(solution string) (int, bool) {
+	j, ok := m.solutionColumns[solution]
+	return j, ok
+}
+
+// The pattern index of the solution in column j
+func (r MatrixRow) At(j int) int {
+	if r.wideIndexes != nil {
+		return int(r.wideIndexes[j])
+	}
+	return int(r.indexes[j])
+}
+
+func (r MatrixRow) set(j int, index int) {
+	if r.wideIndexes != nil {
+		r.wideIndexes[j] = uint16(index)
+	} else {
+		r.indexes[j] = uint8(index)
+	}
+}
+
+// Adds the weight of each column to the count of its pattern
+func (r MatrixRow) count(columns []int, weights []float64, counts []float64) {
+	if r.wideIndexes != nil {
+		countIndexes(r.wideIndexes, columns, weights, counts)
+	} else {
+		countIndexes(r.indexes, columns, weights, counts)
+	}
+}
+
+func countIndexes[T uint8 | uint16](indexes []T, columns []int, weights []float64, counts []float64) {
+	for i, column := range columns {
+		counts[indexes[column]] += weights[i]
+	}
+}
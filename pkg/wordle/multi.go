@@ -0,0 +1,363 @@
+package wordle
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Boards shown next to each other by MultiGame.String
+const boardsPerRow = 4
+
+type (
+	// Solves several boards at once, as in Dordle, Quordle or Octordle, where
+	// every guess is scored against the hidden word of each board.
+	MultiSolver struct {
+		boards     []Solver
+		solved     []bool
+		guesses    []string
+		maxGuesses int
+		scorer     Scorer
+		matrix     *PatternMatrix
+		guessTree  *WordleTree
+		// The boards before each guess, restored by Undo
+		history [][]Solver
+	}
+	// Plays several boards at once, see MultiSolver
+	MultiGame struct {
+		boards     []*Game
+		guesses    []string
+		maxGuesses int
+	}
+)
+
+// Guesses allowed for the number of boards unless WithMaxGuesses is passed,
+// e.g. 9 for Quordle and 13 for Octordle.
+func DefaultMaxGuesses(numBoards int) int {
+	return numBoards + 5
+}
+
+// Creates a solver of numBoards boards, hard mode is not supported
+func NewMultiSolver(numBoards int, opts ...Option) (*MultiSolver, error) {
+	o, err := newMultiOptions(numBoards, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	boards := make([]Solver, numBoards)
+	for i := range boards {
+		if boards[i], err = NewSolver(opts...); err != nil {
+			return nil, err
+		}
+	}
+
+	return &MultiSolver{
+		boards:     boards,
+		solved:     make([]bool, numBoards),
+		maxGuesses: o.maxGuesses,
+		scorer:     o.scorer,
+		matrix:     boards[0].matrix,
+		guessTree:  boards[0].guessTree,
+	}, nil
+}
+
+// Parses the options of a solver or game of numBoards boards, the default guess limit
+// is appended to opts so every board shares it.
+func newMultiOptions(numBoards int, opts *[]Option) (options, error) {
+	if numBoards < 1 {
+		return options{}, fmt.Errorf("Invalid number of boards %d, should be positive", numBoards)
+	}
+
+	// Without defaults to tell whether a guess limit was passed
+	o := options{}
+	for _, opt := range *opts {
+		opt(&o)
+	}
+	if o.maxGuesses == 0 {
+		*opts = append(slices.Clip(*opts), WithMaxGuesses(DefaultMaxGuesses(numBoards)))
+	}
+
+	o, err := newOptions(*opts)
+	if err != nil {
+		return o, err
+	}
+	if o.hardMode {
+		return o, fmt.Errorf("Hard mode is not supported with multiple boards")
+	}
+
+	return o, nil
+}
+
+// Adds a guess with the hints of each board, the hints of solved boards are ignored
+// and may be nil. Returns whether all boards are solved or the guesses ran out, guesses
+// after that are rejected with ErrGameOver.
+func (m *MultiSolver) AddGuess(word string, hints []Pattern) (bool, error) {
+	if !slices.Contains(m.solved, false) {
+		return false, fmt.Errorf("%w, all words have been guessed", ErrGameOver)
+	}
+	if len(m.guesses) >= m.maxGuesses {
+		return false, newGameOverError(m.maxGuesses)
+	}
+	if len(hints) != len(m.boards) {
+		return false, fmt.Errorf("Invalid number of hints %d, should be one per board: %d", len(hints), len(m.boards))
+	}
+
+	// Either every board takes the guess or none
+	prev := slices.Clone(m.boards)
+	for i := range m.boards {
+		if m.solved[i] {
+			continue
+		}
+		if _, err := m.boards[i].AddGuess(word, hints[i]); err != nil {
+			m.boards = prev
+			return false, fmt.Errorf("Board %d: %w", i+1, err)
+		}
+	}
+
+	m.history = append(m.history, prev)
+	m.guesses = append(m.guesses, word)
+	m.updateSolved()
+	return m.Done(), nil
+}
+
+// Removes the last guess
+func (m *MultiSolver) Undo() error {
+	if len(m.history) == 0 {
+		return fmt.Errorf("No guesses to undo")
+	}
+
+	m.boards = m.history[len(m.history)-1]
+	m.history = m.history[:len(m.history)-1]
+	m.guesses = m.guesses[:len(m.guesses)-1]
+	m.updateSolved()
+	return nil
+}
+
+// A board is solved once its last guess is all correct
+func (m *MultiSolver) updateSolved() {
+	for i, board := range m.boards {
+		m.solved[i] = board.state.solved()
+	}
+}
+
+// Whether all boards are solved or the guesses ran out
+func (m *MultiSolver) Done() bool {
+	return !slices.Contains(m.solved, false) || len(m.guesses) >= m.maxGuesses
+}
+
+// The solver of each board
+func (m *MultiSolver) Boards() []Solver {
+	return slices.Clone(m.boards)
+}
+
+// Whether each board is solved
+func (m *MultiSolver) Solved() []bool {
+	return slices.Clone(m.solved)
+}
+
+// The guesses added so far
+func (m *MultiSolver) Guesses() []string {
+	return slices.Clone(m.guesses)
+}
+
+// The number of guesses allowed, see DefaultMaxGuesses
+func (m *MultiSolver) MaxGuesses() int {
+	return m.maxGuesses
+}
+
+// The combined entropy of the unsolved boards in bits
+func (m *MultiSolver) Entropy() (bits float64) {
+	for i := range m.boards {
+		if !m.solved[i] {
+			bits += m.boards[i].Entropy()
+		}
+	}
+
+	return bits
+}
+
+// The n guesses with the highest combined utility over the unsolved boards
+func (m *MultiSolver) Suggestions(n int) []WordUtility {
+	utilities, _ := m.SuggestionsContext(context.Background(), n)
+	return utilities
+}
+
+// Like Suggestions, but stops scoring once the context is done, see Solver.SuggestionsContext.
+// The utility of a guess is the sum of its scores on the unsolved boards, e.g. the combined
+// expected information. ExpectedSolved sums the probabilities of it being the solution of
+// each board, Probability is left 0.
+func (m *MultiSolver) SuggestionsContext(ctx context.Context, n int) ([]WordUtility, error) {
+	var boards []*Solver
+	var columns [][]int
+	var weights [][]float64
+	for i := range m.boards {
+		if !m.solved[i] {
+			boardColumns, boardWeights := m.boards[i].columns()
+			boards = append(boards, &m.boards[i])
+			columns = append(columns, boardColumns)
+			weights = append(weights, boardWeights)
+		}
+	}
+	if len(boards) == 0 {
+		return nil, nil
+	}
+
+	utilities, err := scoreGuesses(ctx, m.matrix, m.guessTree.Wordles, func(row MatrixRow, counts []float64) (utility float64) {
+		for i, board := range boards {
+			utility += board.utility(row, columns[i], weights[i], counts)
+		}
+		return utility
+	})
+
+	for i := range utilities {
+		for _, board := range boards {
+			utilities[i].ExpectedSolved += board.AnswerProbability(utilities[i].Word)
+		}
+	}
+
+	// Prefer likely solutions among equally useful guesses, e.g. once every board is determined
+	sort.Slice(utilities, func(i int, j int) bool {
+		if utilities[i].Utility == utilities[j].Utility {
+			return utilities[i].ExpectedSolved > utilities[j].ExpectedSolved
+		}
+		return ranksBefore(m.scorer, utilities[i].Utility, utilities[j].Utility)
+	})
+
+	n = min(n, len(utilities))
+	return utilities[:n], err
+}
+
+// The guess with the highest combined utility, empty if all boards are solved
+func (m *MultiSolver) BestGuess() string {
+	if utilities := m.Suggestions(1); len(utilities) > 0 {
+		return utilities[0].Word
+	}
+
+	return ""
+}
+
+func (m *MultiSolver) String() string {
+	lines := []string{
+		fmt.Sprintf("Guess %d/%d, uncertainty %.3f bits", len(m.guesses), m.maxGuesses, m.Entropy()),
+	}
+	for i, board := range m.boards {
+		candidates := board.Candidates()
+		line := fmt.Sprintf("Board %d: ", i+1)
+		if m.solved[i] {
+			line += "solved " + strings.ToUpper(board.state.guesses[len(board.state.guesses)-1].word)
+		} else if len(candidates) == 1 {
+			line += "must be " + strings.ToUpper(candidates[0])
+		} else {
+			line += fmt.Sprintf("%d candidates, %.3f bits", len(candidates), board.Entropy())
+			if len(candidates) <= 5 {
+				line += ": " + strings.Join(candidates, " ")
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Creates a game of a board per word
+func NewMultiGame(words []string, opts ...Option) (*MultiGame, error) {
+	o, err := newMultiOptions(len(words), &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	boards := make([]*Game, len(words))
+	for i, word := range words {
+		if boards[i], err = NewGame(word, opts...); err != nil {
+			return nil, err
+		}
+	}
+
+	return &MultiGame{
+		boards:     boards,
+		maxGuesses: o.maxGuesses,
+	}, nil
+}
+
+// Guesses the word on every unsolved board, returns whether all boards are solved
+func (g *MultiGame) Guess(word string) (bool, error) {
+	if g.Solved() {
+		return false, fmt.Errorf("%w, all words have been guessed", ErrGameOver)
+	}
+	if len(g.guesses) >= g.maxGuesses {
+		return false, newGameOverError(g.maxGuesses)
+	}
+
+	// Boards share the rules, a guess is either valid for all of them or for none
+	for _, board := range g.boards {
+		if board.Solved() {
+			continue
+		}
+		if _, err := board.Guess(word); err != nil {
+			return false, err
+		}
+	}
+
+	g.guesses = append(g.guesses, word)
+	return g.Solved(), nil
+}
+
+// The game of each board
+func (g *MultiGame) Boards() []*Game {
+	return slices.Clone(g.boards)
+}
+
+// The guesses made so far
+func (g *MultiGame) Guesses() []string {
+	return slices.Clone(g.guesses)
+}
+
+// Whether every board is solved
+func (g *MultiGame) Solved() bool {
+	for _, board := range g.boards {
+		if !board.Solved() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Whether every board is solved or the guesses have run out
+func (g *MultiGame) Over() bool {
+	return g.Solved() || len(g.guesses) >= g.maxGuesses
+}
+
+// The hidden words, to reveal once the game is over
+func (g *MultiGame) Words() []string {
+	words := make([]string, len(g.boards))
+	for i, board := range g.boards {
+		words[i] = board.word
+	}
+
+	return words
+}
+
+// Shows the boards next to each other, boardsPerRow at a time
+func (g *MultiGame) String() string {
+	output := strings.Builder{}
+	for start := 0; start < len(g.boards); start += boardsPerRow {
+		var columns [][]string
+		for _, board := range g.boards[start:min(start+boardsPerRow, len(g.boards))] {
+			lines := strings.Split(board.String(), "\n")
+			padRightLines(lines)
+			columns = append(columns, lines)
+		}
+
+		for i := range columns[0] {
+			for _, lines := range columns {
+				output.WriteString(lines[i] + "  ")
+			}
+			output.WriteString("\n")
+		}
+	}
+
+	return output.String()
+}
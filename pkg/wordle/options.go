@@ -0,0 +1,84 @@
+package wordle
+
+import "fmt"
+
+type (
+	// Configures a Game or Solver
+	Option  func(*options)
+	options struct {
+		hardMode   bool
+		dictionary *Dictionary
+		strategy   *Strategy
+		scorer     Scorer
+		priors     Priors
+		maxGuesses int
+	}
+)
+
+func newOptions(opts []Option) (options, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.maxGuesses == 0 {
+		o.maxGuesses = MaxGuesses
+	} else if o.maxGuesses < 0 {
+		return o, fmt.Errorf("Invalid maximum number of guesses %d, should be positive", o.maxGuesses)
+	}
+	if o.scorer == nil {
+		o.scorer = EntropyScorer{}
+	}
+	if o.dictionary == nil {
+		var err error
+		if o.dictionary, err = LoadDefaultDictionary(); err != nil {
+			return o, err
+		}
+	}
+
+	return o, nil
+}
+
+// Hard mode requires every guess to use all revealed hints, greens
+// must stay in place and yellows must be included.
+func WithHardMode() Option {
+	return func(o *options) {
+		o.hardMode = true
+	}
+}
+
+// Plays with the words of a custom dictionary instead of the default dictionary
+func WithDictionary(dictionary *Dictionary) Option {
+	return func(o *options) {
+		o.dictionary = dictionary
+	}
+}
+
+// Solves with a precomputed strategy instead of the strategy of the dictionary, if any.
+// Strategies are not used in hard mode.
+func WithStrategy(strategy *Strategy) Option {
+	return func(o *options) {
+		o.strategy = strategy
+	}
+}
+
+// Ranks the solver's suggestions with the scorer instead of the expected information
+func WithScorer(scorer Scorer) Option {
+	return func(o *options) {
+		o.scorer = scorer
+	}
+}
+
+// Weighs the solutions by the priors, without priors every solution is equally likely
+func WithPriors(priors Priors) Option {
+	return func(o *options) {
+		o.priors = priors
+	}
+}
+
+// Allows n guesses instead of MaxGuesses, e.g. 9 for Quordle
+func WithMaxGuesses(n int) Option {
+	return func(o *options) {
+		o.maxGuesses = n
+	}
+}
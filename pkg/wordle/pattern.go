@@ -0,0 +1,168 @@
+package wordle
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Letters of the pattern strings, see Pattern.String
+const (
+	patternCorrect    = 'G'
+	patternTransposed = 'Y'
+	patternWrong      = '-'
+	patternPossible   = '.'
+)
+
+// Emoji of the shared results, see Pattern.Emoji
+const (
+	emojiCorrect    = "🟩"
+	emojiTransposed = "🟨"
+	emojiWrong      = "⬛"
+)
+
+// The hints of guess for the answer, duplicate letters are only marked as often
+// as they occur in the answer, correct letters first. Returns nil if the lengths differ.
+func Score(guess string, answer string) Pattern {
+	if len(guess) != len(answer) || len(guess) > MaxWordLength {
+		return nil
+	}
+
+	hints := make(Pattern, len(guess))
+	scoreInto(guess, answer, hints)
+	return hints
+}
+
+// Computes the hints of guess for the answer into hints, all must be of equal length.
+func scoreInto(guess string, answer string, hints []Hint) {
+	var bag [MaxWordLength]byte
+	copy(bag[:], answer)
+
+	// Check correct letter
+	for i := range hints {
+		hints[i] = LetterWrong
+		if guess[i] == bag[i] {
+			// Prevent duplicate yellows by removing letters from the word/bag
+			bag[i] = '?'
+			hints[i] = LetterCorrect
+		}
+	}
+	// Check transposed letters
+	for i := range hints {
+		if hints[i] == LetterCorrect {
+			continue
+		}
+		if index := slices.Index(bag[:len(hints)], guess[i]); index != -1 {
+			// Prevent duplicate yellows by removing letters from the word/bag
+			bag[index] = '?'
+			hints[i] = LetterTransposed
+		}
+	}
+}
+
+// Index of the pattern in AllPatterns, between 0 and NumPatterns(len(p)) - 1
+func (p Pattern) Index() int {
+	return patternIndex(p)
+}
+
+// The pattern of the index in AllPatterns
+func PatternFromIndex(index int, wordLength int) (Pattern, error) {
+	if wordLength < MinWordLength || wordLength > MaxWordLength {
+		return nil, fmt.Errorf("Invalid word length %d, should be between %d and %d", wordLength, MinWordLength, MaxWordLength)
+	}
+	if index < 0 || index >= NumPatterns(wordLength) {
+		return nil, fmt.Errorf("Invalid pattern index %d, should be between 0 and %d", index, NumPatterns(wordLength)-1)
+	}
+
+	return slices.Clone(AllPatterns(wordLength)[index]), nil
+}
+
+// Whether every hint is correct
+func (p Pattern) Solved() bool {
+	for _, hint := range p {
+		if hint != LetterCorrect {
+			return false
+		}
+	}
+
+	return len(p) > 0
+}
+
+// Formats the pattern as e.g. 'GY---', 'G' is correct, 'Y' is transposed
+// and '-' is wrong. Hints that are not known yet are formatted as '.'.
+func (p Pattern) String() string {
+	output := strings.Builder{}
+	for _, hint := range p {
+		switch hint {
+		case LetterCorrect:
+			output.WriteByte(patternCorrect)
+		case LetterTransposed:
+			output.WriteByte(patternTransposed)
+		case LetterWrong:
+			output.WriteByte(patternWrong)
+		default:
+			output.WriteByte(patternPossible)
+		}
+	}
+
+	return output.String()
+}
+
+// Formats the pattern as a row of emoji, as shared by Wordle
+func (p Pattern) Emoji() string {
+	output := strings.Builder{}
+	for _, hint := range p {
+		switch hint {
+		case LetterCorrect:
+			output.WriteString(emojiCorrect)
+		case LetterTransposed:
+			output.WriteString(emojiTransposed)
+		default:
+			output.WriteString(emojiWrong)
+		}
+	}
+
+	return output.String()
+}
+
+// Parses a pattern of the given length, either as formatted by String or Emoji.
+// Letters are case insensitive, 'g' is correct, 'y' is transposed and '-', '.', '_',
+// 'x', 'b' or a space are wrong. Missing hints at the end are wrong, e.g. 'g' is 'G----'.
+// The emoji of the high contrast mode and the light mode are accepted as well.
+func ParsePattern(s string, wordLength int) (Pattern, error) {
+	if utf8.RuneCountInString(s) > wordLength {
+		return nil, fmt.Errorf("%w of hints %d, should be %d", ErrWrongLength, utf8.RuneCountInString(s), wordLength)
+	}
+
+	pattern := make(Pattern, wordLength)
+	for i := range pattern {
+		pattern[i] = LetterWrong
+	}
+
+	i := 0
+	for _, r := range s {
+		switch r {
+		case 'g', 'G', '🟩', '🟧':
+			pattern[i] = LetterCorrect
+		case 'y', 'Y', '🟨', '🟦':
+			pattern[i] = LetterTransposed
+		case '-', '.', '_', 'x', 'X', 'b', 'B', ' ', '⬛', '⬜':
+			pattern[i] = LetterWrong
+		default:
+			return nil, fmt.Errorf("%w: hint '%c' at %s letter, should be g, y or -", ErrInvalidCharacters, r, ordinal(i+1))
+		}
+		i++
+	}
+
+	return pattern, nil
+}
+
+// Like ParsePattern, but requires a hint for every letter, e.g. for hints that are not typed in
+func ParseExactPattern(s string, wordLength int) (Pattern, error) {
+	if n := utf8.RuneCountInString(s); n != wordLength {
+		return nil, fmt.Errorf("%w of hints %d, should be %d", ErrWrongLength, n, wordLength)
+	}
+
+	return ParsePattern(s, wordLength)
+}
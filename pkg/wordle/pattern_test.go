@@ -0,0 +1,88 @@
+package wordle
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		guess    string
+		answer   string
+		expected string
+	}{
+		{"crane", "crane", "GGGGG"},
+		{"fuzzy", "crane", "-----"},
+		// The second E is wrong as abide has a single E
+		{"speed", "abide", "--Y-Y"},
+		{"eerie", "there", "Y-Y-G"},
+		// Correct letters are marked before transposed ones
+		{"geese", "eerie", "-GY-G"},
+		{"eerie", "steed", "YY---"},
+		{"abbey", "kebab", "YYGY-"},
+		{"llama", "hello", "YY---"},
+		{"sassy", "grass", "YY-G-"},
+		// Only the first of the remaining Ms is transposed
+		{"mamma", "madam", "GGY-Y"},
+		{"error", "roars", "-YYY-"},
+	}
+
+	for _, test := range tests {
+		if hints := Score(test.guess, test.answer).String(); hints != test.expected {
+			t.Errorf("Score(%s, %s): expected %s, got %s", test.guess, test.answer, test.expected, hints)
+		}
+	}
+
+	if hints := Score("crane", "cranes"); hints != nil {
+		t.Errorf("Score of different lengths: expected nil, got %s", hints)
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected string
+		err      error
+	}{
+		{"GY---", "GY---", nil},
+		{"gy-x.", "GY---", nil},
+		{"g", "G----", nil},
+		{"🟩🟨⬛⬛⬜", "GY---", nil},
+		{"🟧🟦⬛⬛⬛", "GY---", nil},
+		{"gy----", "", ErrWrongLength},
+		{"gyz--", "", ErrInvalidCharacters},
+	}
+
+	for _, test := range tests {
+		pattern, err := ParsePattern(test.s, 5)
+		if !errors.Is(err, test.err) {
+			t.Errorf("ParsePattern(%s): expected error %v, got %v", test.s, test.err, err)
+		}
+		if err == nil && pattern.String() != test.expected {
+			t.Errorf("ParsePattern(%s): expected %s, got %s", test.s, test.expected, pattern)
+		}
+	}
+
+	if _, err := ParseExactPattern("g", 5); !errors.Is(err, ErrWrongLength) {
+		t.Errorf("ParseExactPattern(g): expected %v, got %v", ErrWrongLength, err)
+	}
+}
+
+func TestPatternEncoding(t *testing.T) {
+	for wordLength := MinWordLength; wordLength <= 6; wordLength++ {
+		for index, pattern := range AllPatterns(wordLength) {
+			if pattern.Index() != index {
+				t.Fatalf("%s: expected index %d, got %d", pattern, index, pattern.Index())
+			}
+			decoded, err := PatternFromIndex(index, wordLength)
+			if err != nil || !slices.Equal(decoded, pattern) {
+				t.Fatalf("PatternFromIndex(%d, %d): expected %s, got %s (%v)", index, wordLength, pattern, decoded, err)
+			}
+			parsed, err := ParsePattern(pattern.Emoji(), wordLength)
+			if err != nil || !slices.Equal(parsed, pattern) {
+				t.Fatalf("ParsePattern(%s): expected %s, got %s (%v)", pattern.Emoji(), pattern, parsed, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package wordle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Relative frequencies of words, frequent words are considered more likely
+// solutions. The weights need not sum up to 1.
+type Priors map[string]float64
+
+// Loads priors from a file, see ParsePriors for the supported formats.
+// Returns nil priors for an empty path, i.e. equally likely solutions.
+func LoadPriors(path string) (Priors, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read priors '%s': %w", path, err)
+	}
+
+	priors, err := ParsePriors(data)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse priors '%s': %w", path, err)
+	}
+
+	return priors, nil
+}
+
+// Parses either a JSON object of words to weights, or plain text with a word and its
+// weight per line. Blank lines and lines starting with '#' are skipped, words are lowercased.
+func ParsePriors(data []byte) (Priors, error) {
+	priors := make(Priors)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var weights map[string]float64
+		if err := json.Unmarshal(trimmed, &weights); err != nil {
+			return nil, fmt.Errorf("Failed to parse JSON: %w", err)
+		}
+		for word, weight := range weights {
+			if math.IsInf(weight, 0) || math.IsNaN(weight) {
+				return nil, fmt.Errorf("Invalid weight of '%s', should be finite", word)
+			}
+			priors[strings.ToLower(strings.TrimSpace(word))] = weight
+		}
+		return priors, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("Invalid line %d, should be a word and its weight", line)
+		}
+		weight, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid weight on line %d: %w", line, err)
+		} else if math.IsInf(weight, 0) || math.IsNaN(weight) {
+			return nil, fmt.Errorf("Invalid weight on line %d, should be finite", line)
+		}
+		priors[strings.ToLower(fields[0])] = weight
+	}
+
+	return priors, scanner.Err()
+}
+
+// Weights of the wordles, wordles without a positive prior get the
+// smallest positive prior of the wordles, as these are still possible.
+func (p Priors) weights(wordles []string) map[string]float64 {
+	minWeight := math.Inf(1)
+	for _, word := range wordles {
+		if weight := p[word]; weight > 0 {
+			minWeight = min(minWeight, weight)
+		}
+	}
+	if math.IsInf(minWeight, 1) {
+		minWeight = 1
+	}
+
+	weights := make(map[string]float64, len(wordles))
+	for _, word := range wordles {
+		weights[word] = minWeight
+		if weight := p[word]; weight > 0 {
+			weights[word] = weight
+		}
+	}
+
+	return weights
+}
@@ -0,0 +1,36 @@
+package wordle
+
+import "testing"
+
+func TestParsePriors(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected Priors
+		fails    bool
+	}{
+		{`{"Crane": 2, "abide": 0.5}`, Priors{"crane": 2, "abide": 0.5}, false},
+		{"# frequencies\nCrane 2\n\nabide 0.5\n", Priors{"crane": 2, "abide": 0.5}, false},
+		{"crane", nil, true},
+		{"crane many", nil, true},
+		// Non-finite weights would spread through the entropy of every guess
+		{"crane Inf", nil, true},
+		{"crane -inf", nil, true},
+		{"crane NaN", nil, true},
+	}
+
+	for _, test := range tests {
+		priors, err := ParsePriors([]byte(test.data))
+		if (err != nil) != test.fails {
+			t.Errorf("ParsePriors(%q): expected failure %t, got %v", test.data, test.fails, err)
+			continue
+		}
+		if len(priors) != len(test.expected) {
+			t.Errorf("ParsePriors(%q): expected %v, got %v", test.data, test.expected, priors)
+		}
+		for word, weight := range test.expected {
+			if priors[word] != weight {
+				t.Errorf("ParsePriors(%q): expected %s to weigh %g, got %g", test.data, word, weight, priors[word])
+			}
+		}
+	}
+}
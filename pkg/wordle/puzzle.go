@@ -0,0 +1,122 @@
+package wordle
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// The date of the first daily puzzle, numbered 0 like Wordle's
+var DailyEpoch = time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)
+
+type (
+	// A hidden word and the number it is shared by
+	Puzzle struct {
+		Number int
+		Word   string
+	}
+	// Picks the hidden words of games, see NewPuzzleGame
+	PuzzleSource interface {
+		Next() (Puzzle, error)
+	}
+	// The puzzle of the day, the same for everyone sharing the seed
+	DailyPuzzles struct {
+		words []string
+		// Order of the words, a permutation so no word repeats within a cycle
+		order []int
+		// The current time, replaceable to play the puzzle of another day
+		Now func() time.Time
+	}
+	// Words picked at random, the same sequence for everyone sharing the seed
+	RandomPuzzles struct {
+		words  []string
+		rand   *rand.Rand
+		number int
+	}
+	// Words in the order of a list, e.g. to replay a set of puzzles
+	ListPuzzles struct {
+		words  []string
+		number int
+	}
+)
+
+// Daily puzzles of the words shuffled by the seed
+func NewDailyPuzzles(words []string, seed uint64) *DailyPuzzles {
+	return &DailyPuzzles{
+		words: words,
+		order: rand.New(rand.NewPCG(seed, seed)).Perm(len(words)),
+		Now:   time.Now,
+	}
+}
+
+// The puzzle of today, the same until midnight in the local time zone
+func (d *DailyPuzzles) Next() (Puzzle, error) {
+	return d.PuzzleOn(d.Now())
+}
+
+// The puzzle of the date, its number is the number of days since DailyEpoch
+func (d *DailyPuzzles) PuzzleOn(date time.Time) (Puzzle, error) {
+	if len(d.words) == 0 {
+		return Puzzle{}, fmt.Errorf("No words to pick puzzles from")
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	number := int(day.Sub(DailyEpoch).Hours() / 24)
+	if number < 0 {
+		return Puzzle{}, fmt.Errorf("No puzzles before %s", DailyEpoch.Format(time.DateOnly))
+	}
+
+	return Puzzle{Number: number, Word: d.words[d.order[number%len(d.words)]]}, nil
+}
+
+// Random puzzles of the words, numbered from 1 in the order they are picked
+func NewRandomPuzzles(words []string, seed uint64) *RandomPuzzles {
+	return &RandomPuzzles{
+		words: words,
+		rand:  rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+func (r *RandomPuzzles) Next() (Puzzle, error) {
+	if len(r.words) == 0 {
+		return Puzzle{}, fmt.Errorf("No words to pick puzzles from")
+	}
+
+	r.number++
+	return Puzzle{Number: r.number, Word: r.words[r.rand.IntN(len(r.words))]}, nil
+}
+
+// Puzzles of the words in order, numbered from 1
+func NewListPuzzles(words []string) *ListPuzzles {
+	return &ListPuzzles{words: words}
+}
+
+// The next word of the list, an error once all have been played
+func (l *ListPuzzles) Next() (Puzzle, error) {
+	if l.number >= len(l.words) {
+		return Puzzle{}, fmt.Errorf("All %d puzzles have been played", len(l.words))
+	}
+
+	l.number++
+	return Puzzle{Number: l.number, Word: l.words[l.number-1]}, nil
+}
+
+// Creates a game of the next puzzle of the source
+func NewPuzzleGame(source PuzzleSource, opts ...Option) (*Game, error) {
+	puzzle, err := source.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := NewGame(puzzle.Word, opts...)
+	if err != nil {
+		return nil, err
+	}
+	game.number = puzzle.Number
+	return game, nil
+}
+
+// The number of the puzzle, 0 if the game is not of a PuzzleSource
+func (g *Game) Number() int {
+	return g.number
+}
@@ -0,0 +1,147 @@
+package wordle
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+type (
+	// Scores a guess by how it partitions the remaining solutions, higher is better
+	// unless the scorer implements Minimizer.
+	Scorer interface {
+		Score(p Partition) float64
+		// Describes the score, shown above the suggestions
+		String() string
+	}
+	// Implemented by scorers of costs, these rank lower scores first
+	Minimizer interface {
+		Minimize()
+	}
+	// How a guess partitions the remaining solutions
+	Partition struct {
+		// Remaining solutions for each pattern the guess can yield,
+		// weighted by their priors if any. The counts sum up to Total.
+		Counts []float64
+		Total  float64
+		// Number of remaining solutions
+		Solutions int
+		// Count of the solved pattern, 0 if the guess is not a possible solution
+		Solved float64
+	}
+	// Expected information in bits, the default
+	EntropyScorer struct{}
+	// Solutions eliminated in the worst case, i.e. minimizes the largest remaining set
+	MinimaxScorer struct{}
+	// Number of distinct patterns, i.e. maximizes the number of remaining sets
+	PatternsScorer struct{}
+	// Expected number of solutions eliminated, i.e. minimizes the expected remaining set
+	ExpectedSizeScorer struct{}
+	// Expected number of guesses to solve, including this guess. Guessing a possible
+	// solution may win right away, which entropy alone does not reward.
+	ExpectedGuessesScorer struct{}
+)
+
+var scorers = map[string]Scorer{
+	"entropy":  EntropyScorer{},
+	"minimax":  MinimaxScorer{},
+	"patterns": PatternsScorer{},
+	"expected": ExpectedSizeScorer{},
+	"guesses":  ExpectedGuessesScorer{},
+}
+
+// Names accepted by ParseScorer
+func ScorerNames() []string {
+	names := make([]string, 0, len(scorers))
+	for name := range scorers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// Parses the name of a scorer, see ScorerNames
+func ParseScorer(name string) (Scorer, error) {
+	scorer, ok := scorers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("Unknown scorer '%s', should be one of: %s", name, strings.Join(ScorerNames(), ", "))
+	}
+
+	return scorer, nil
+}
+
+func (EntropyScorer) Score(p Partition) float64 {
+	return entropy(p.Counts, p.Total)
+}
+
+func (EntropyScorer) String() string {
+	return "Expected utility in bits"
+}
+
+func (MinimaxScorer) Score(p Partition) float64 {
+	return p.Total - slices.Max(p.Counts)
+}
+
+func (MinimaxScorer) String() string {
+	return "Eliminated in the worst case"
+}
+
+func (PatternsScorer) Score(p Partition) float64 {
+	return float64(len(p.Counts))
+}
+
+func (PatternsScorer) String() string {
+	return "Distinct patterns"
+}
+
+func (ExpectedSizeScorer) Score(p Partition) float64 {
+	// Each solution remains with the others of its pattern
+	var expected float64
+	for _, count := range p.Counts {
+		expected += count * count / p.Total
+	}
+
+	return p.Total - expected
+}
+
+func (ExpectedSizeScorer) String() string {
+	return "Expected eliminated"
+}
+
+func (ExpectedGuessesScorer) Score(p Partition) float64 {
+	// Every pattern but the solved one takes the guesses to solve its solutions
+	expected := 1.0
+	for _, count := range p.Counts {
+		expected += count / p.Total * guessesToSolve(count/p.Total*float64(p.Solutions))
+	}
+	expected -= p.Solved / p.Total * guessesToSolve(p.Solved/p.Total*float64(p.Solutions))
+
+	return expected
+}
+
+func (ExpectedGuessesScorer) String() string {
+	return "Expected guesses"
+}
+
+func (ExpectedGuessesScorer) Minimize() {}
+
+// Rough estimate of the guesses to solve n equally likely solutions, one for a single
+// solution, 1.5 for two, growing with about 4 bits of information per guess.
+func guessesToSolve(n float64) float64 {
+	if n <= 1 {
+		return 1
+	}
+
+	return 1 + (1-1/n)*max(1, math.Log2(n)/4)
+}
+
+// Reports whether the scorer ranks score a before score b
+func ranksBefore(scorer Scorer, a float64, b float64) bool {
+	if _, ok := scorer.(Minimizer); ok {
+		return a < b
+	}
+
+	return a > b
+}
@@ -0,0 +1,191 @@
+package wordle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Kinds of saved sessions
+const (
+	SessionGame   = "game"
+	SessionSolver = "solver"
+)
+
+type (
+	// Saved guesses of a Game or Solver, resumed by replaying them
+	Session struct {
+		Kind       string `json:"kind"`
+		HardMode   bool   `json:"hardMode,omitempty"`
+		MaxGuesses int    `json:"maxGuesses,omitempty"`
+		// Hidden word of a game, ROT13 encoded if obscured to not spoil it
+		Word     string `json:"word,omitempty"`
+		Obscured bool   `json:"obscured,omitempty"`
+		// Number of the puzzle of a game, see NewPuzzleGame
+		Number int `json:"number,omitempty"`
+		// Games without a word, replaying the guesses yields the same hints
+		Adversarial bool           `json:"adversarial,omitempty"`
+		Guesses     []SessionGuess `json:"guesses"`
+	}
+	SessionGuess struct {
+		Word string `json:"word"`
+		// Formatted like Pattern.String
+		Hints string `json:"hints"`
+	}
+)
+
+// Saves the guesses and the hidden word of the game, obscure hides the word from a glance at the file
+func (g *Game) Session(obscure bool) Session {
+	word := g.word
+	if obscure {
+		word = rot13(word)
+	}
+
+	return Session{
+		Kind:        SessionGame,
+		HardMode:    g.state.hardMode,
+		MaxGuesses:  g.state.maxGuesses,
+		Word:        word,
+		Obscured:    obscure,
+		Number:      g.number,
+		Adversarial: g.Adversarial(),
+		Guesses:     newSessionGuesses(g.Guesses()),
+	}
+}
+
+// Saves the guesses of the solver
+func (s *Solver) Session() Session {
+	return Session{
+		Kind:       SessionSolver,
+		HardMode:   s.hardMode,
+		MaxGuesses: s.state.maxGuesses,
+		Guesses:    newSessionGuesses(s.Guesses()),
+	}
+}
+
+func newSessionGuesses(guesses []*Guess) []SessionGuess {
+	res := make([]SessionGuess, len(guesses))
+	for i, guess := range guesses {
+		res[i] = SessionGuess{guess.word, guess.hints.String()}
+	}
+
+	return res
+}
+
+// Writes the session to a JSON file
+func (s Session) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("Unable to save session '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// Reads a session saved by Session.Save
+func LoadSession(path string) (Session, error) {
+	var s Session
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, fmt.Errorf("Unable to read session '%s': %w", path, err)
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("Unable to parse session '%s': %w", path, err)
+	}
+	if s.Kind != SessionGame && s.Kind != SessionSolver {
+		return s, fmt.Errorf("Unknown session kind '%s', should be '%s' or '%s'", s.Kind, SessionGame, SessionSolver)
+	}
+
+	return s, nil
+}
+
+// Restores a game by replaying the guesses, the saved hints must match the hidden word
+func (s Session) ResumeGame(opts ...Option) (*Game, error) {
+	if s.Kind != SessionGame {
+		return nil, fmt.Errorf("Session is a %s, not a %s", s.Kind, SessionGame)
+	}
+
+	word := s.Word
+	if s.Obscured {
+		word = rot13(word)
+	}
+	var game *Game
+	var err error
+	if s.Adversarial {
+		game, err = NewAdversarialGame(s.options(opts)...)
+	} else {
+		game, err = NewGame(word, s.options(opts)...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	game.number = s.Number
+
+	for i, guess := range s.Guesses {
+		hints, err := ParsePattern(guess.Hints, len(guess.Word))
+		if err != nil {
+			return nil, fmt.Errorf("Guess %d: %w", i+1, err)
+		}
+		if _, err := game.Guess(guess.Word); err != nil {
+			return nil, fmt.Errorf("Guess %d: %w", i+1, err)
+		}
+		if played := game.Guesses()[i]; !slices.Equal(played.hints, hints) {
+			return nil, fmt.Errorf("Guess %d: saved hints %s do not match %s", i+1, hints, played.hints)
+		}
+	}
+
+	return game, nil
+}
+
+// Restores a solver by replaying the guesses through Solver.AddGuess
+func (s Session) ResumeSolver(opts ...Option) (Solver, error) {
+	if s.Kind != SessionSolver {
+		return Solver{}, fmt.Errorf("Session is a %s, not a %s", s.Kind, SessionSolver)
+	}
+
+	solver, err := NewSolver(s.options(opts)...)
+	if err != nil {
+		return Solver{}, err
+	}
+
+	for i, guess := range s.Guesses {
+		hints, err := ParsePattern(guess.Hints, len(guess.Word))
+		if err != nil {
+			return Solver{}, fmt.Errorf("Guess %d: %w", i+1, err)
+		}
+		if _, err := solver.AddGuess(guess.Word, hints); err != nil {
+			return Solver{}, fmt.Errorf("Guess %d: %w", i+1, err)
+		}
+	}
+
+	return solver, nil
+}
+
+// Appends the saved rules to opts
+func (s Session) options(opts []Option) []Option {
+	opts = slices.Clip(opts)
+	if s.HardMode {
+		opts = append(opts, WithHardMode())
+	}
+	if s.MaxGuesses > 0 {
+		opts = append(opts, WithMaxGuesses(s.MaxGuesses))
+	}
+
+	return opts
+}
+
+// Rotates the letters by 13, i.e. obscures and reveals a word
+func rot13(word string) string {
+	rotated := []byte(word)
+	for i, char := range rotated {
+		if char >= 'a' && char <= 'z' {
+			rotated[i] = 'a' + (char-'a'+13)%26
+		}
+	}
+
+	return string(rotated)
+}
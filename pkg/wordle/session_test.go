@@ -0,0 +1,143 @@
+package wordle
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSessionResumeGame(t *testing.T) {
+	game, err := NewGame("abide")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, guess := range []string{"speed", "crane"} {
+		if _, err := game.Guess(guess); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "game.json")
+	if err := game.Session(true).Save(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "abide") || !strings.Contains(string(data), rot13("abide")) {
+		t.Errorf("Expected the word to be obscured, got %s", data)
+	}
+
+	session, err := LoadSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed, err := session.ResumeGame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed.word != "abide" {
+		t.Errorf("Expected the word abide, got %s", resumed.word)
+	}
+	assertSameGuesses(t, game.Guesses(), resumed.Guesses())
+}
+
+func TestSessionResumeSolver(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := solver.AddGuess("speed", Score("speed", "abide")); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "solver.json")
+	if err := solver.Session().Save(path); err != nil {
+		t.Fatal(err)
+	}
+	session, err := LoadSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed, err := session.ResumeSolver()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSameGuesses(t, solver.Guesses(), resumed.Guesses())
+	if !slices.Equal(solver.Candidates(), resumed.Candidates()) {
+		t.Errorf("Expected candidates %v, got %v", solver.Candidates(), resumed.Candidates())
+	}
+	if _, err := session.ResumeGame(); err == nil {
+		t.Errorf("Expected a solver session not to resume as a game")
+	}
+}
+
+func TestSessionResumeAdversarialGame(t *testing.T) {
+	game, err := NewAdversarialGame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, guess := range []string{"raise", "count"} {
+		if _, err := game.Guess(guess); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	session := game.Session(false)
+	if !session.Adversarial || session.Word != "" {
+		t.Errorf("Expected an adversarial session without a word, got %+v", session)
+	}
+	resumed, err := session.ResumeGame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resumed.Adversarial() {
+		t.Errorf("Expected the resumed game to be adversarial")
+	}
+	assertSameGuesses(t, game.Guesses(), resumed.Guesses())
+	if resumed.remaining.WordCount != game.remaining.WordCount {
+		t.Errorf("Expected %d remaining solutions, got %d", game.remaining.WordCount, resumed.remaining.WordCount)
+	}
+
+	// The adversary picks the same hints again, others do not match
+	session.Guesses[1].Hints = "GGGGG"
+	if _, err := session.ResumeGame(); err == nil {
+		t.Errorf("Expected the changed hints not to match")
+	}
+}
+
+func TestSessionRejectsMismatchedHints(t *testing.T) {
+	session := Session{
+		Kind:    SessionGame,
+		Word:    "abide",
+		Guesses: []SessionGuess{{"speed", "--Y-Y"}, {"crane", "GGGGG"}},
+	}
+	if _, err := session.ResumeGame(); err == nil || !strings.Contains(err.Error(), "Guess 2") {
+		t.Errorf("Expected the hints of guess 2 not to match, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "unknown.json")
+	if err := os.WriteFile(path, []byte(`{"kind": "unknown", "guesses": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSession(path); err == nil {
+		t.Errorf("Expected an unknown kind to be rejected")
+	}
+}
+
+func assertSameGuesses(t *testing.T, expected []*Guess, actual []*Guess) {
+	t.Helper()
+	if len(expected) != len(actual) {
+		t.Fatalf("Expected %d guesses, got %d", len(expected), len(actual))
+	}
+	for i := range expected {
+		if expected[i].Word() != actual[i].Word() || !slices.Equal(expected[i].Hints(), actual[i].Hints()) {
+			t.Errorf("Guess %d: expected %s %s, got %s %s", i+1,
+				expected[i].Word(), expected[i].Hints(), actual[i].Word(), actual[i].Hints())
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package wordle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Formats the guesses as the shareable grid of emoji, headed by e.g. 'Wordle 1,234 3/6*'.
+// The number of the puzzle is left out if it is 0, the '*' marks hard mode.
+func (gs *GameState) Share(number int) string {
+	guesses := gs.Guesses()
+
+	score := "X"
+	if len(guesses) > 0 && guesses[len(guesses)-1].hints.Solved() {
+		score = strconv.Itoa(len(guesses))
+	}
+	header := fmt.Sprintf("Wordle %s/%d", score, gs.maxGuesses)
+	if number > 0 {
+		header = fmt.Sprintf("Wordle %s %s/%d", formatThousands(number), score, gs.maxGuesses)
+	}
+	if gs.hardMode {
+		header += "*"
+	}
+
+	output := strings.Builder{}
+	output.WriteString(header + "\n")
+	for _, guess := range guesses {
+		output.WriteString("\n" + guess.hints.Emoji())
+	}
+
+	return output.String()
+}
+
+// Formats n with comma separated thousands, e.g. 1,234
+func formatThousands(n int) string {
+	digits := strconv.Itoa(n)
+	output := strings.Builder{}
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			output.WriteByte(',')
+		}
+		output.WriteRune(digit)
+	}
+
+	return output.String()
+}
+
+// Parses the patterns of a shared grid, see GameState.Share. Lines that are not
+// rows of emoji, such as the header, are skipped.
+func ParseShare(s string, wordLength int) ([]Pattern, error) {
+	var patterns []Pattern
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if !isEmojiRow(line) {
+			continue
+		}
+		if utf8.RuneCountInString(line) != wordLength {
+			return nil, fmt.Errorf("Invalid length of row %d, should be %d", len(patterns)+1, wordLength)
+		}
+
+		pattern, err := ParsePattern(line, wordLength)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("Shared grid has no rows")
+	}
+
+	return patterns, nil
+}
+
+func isEmojiRow(line string) bool {
+	for _, r := range line {
+		switch r {
+		case '🟩', '🟨', '⬛', '⬜', '🟧', '🟦':
+		default:
+			return false
+		}
+	}
+	return len(line) > 0
+}
+
+// Formats the shareable grid of the game, headed by the number of its puzzle, see GameState.Share
+func (g *Game) Share() string {
+	return g.state.Share(g.number)
+}
+
+// Formats the shareable grid of the guesses, see GameState.Share
+func (s *Solver) Share(number int) string {
+	return s.state.Share(number)
+}
+
+// Adds the guesses of a shared grid, the rows of which are paired with the words
+// in order. Returns whether the solver is done, like AddGuess.
+func (s *Solver) AddShare(grid string, words []string) (bool, error) {
+	patterns, err := ParseShare(grid, s.wordLength)
+	if err != nil {
+		return false, err
+	}
+	if len(words) != len(patterns) {
+		return false, fmt.Errorf("Shared grid has %d rows, but %d words were given", len(patterns), len(words))
+	}
+
+	// Either all rows are added or none
+	numGuesses := len(s.Guesses())
+	var done bool
+	for i, word := range words {
+		if done, err = s.AddGuess(word, patterns[i]); err != nil {
+			s.Rewind(numGuesses)
+			return false, fmt.Errorf("Row %d: %w", i+1, err)
+		}
+	}
+
+	return done, nil
+}
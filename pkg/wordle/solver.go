@@ -1,30 +1,37 @@
 package wordle
 
 import (
+	"cmp"
+	"context"
 	"fmt"
 	"math"
+	"runtime"
 	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"unicode"
-
-	"github.com/Backshifted/wordle-solver/assets"
 )
 
 type (
-	Pattern [5]Hint
+	Pattern []Hint
 	// Each bit is a unique letter
 	// bit 1 = a, bit 2 = b, etc.
-	LetterConstraint   uint32
-	MinCountConstraint struct {
-		Char  byte
-		Count byte
+	LetterConstraint uint32
+	// Bounds the number of occurrences of a letter, a Max of
+	// UnboundedCount means the letter may occur any number of times.
+	CountConstraint struct {
+		Char byte
+		Min  byte
+		Max  byte
 	}
+	// Positions beyond the word length are ignored, which keeps
+	// constraints comparable and independent of the word length.
 	Constraint struct {
-		Letters [5]LetterConstraint
-		// A static length array saves spaces over a map, 10 vs 48 bytes.
-		Counts [5]MinCountConstraint
+		Letters [MaxWordLength]LetterConstraint
+		// A static length array saves spaces over a map, 24 vs 48 bytes.
+		Counts [MaxWordLength]CountConstraint
 	}
 	WordleTreeNode struct {
 		Options  LetterConstraint
@@ -32,26 +39,31 @@ type (
 	}
 	WordleTree struct {
 		WordleTreeNode
-		WordCount int
-		Wordles   []string
+		WordLength int
+		WordCount  int
+		Wordles    []string
 	}
 	WordUtility struct {
 		Word    string
 		Utility float64
+		// Probability that the word is the solution
+		Probability float64
+		// Expected number of boards the word solves, only set by MultiSolver
+		ExpectedSolved float64
+	}
+	PatternProbability struct {
+		Pattern Pattern
+		// Number of candidates yielding the pattern
+		Count       int
+		Probability float64
 	}
-	ConstraintMap map[string][]Constraint
 )
 
+const UnboundedCount = math.MaxUint8
+
 var (
-	Hints              = [3]Hint{LetterWrong, LetterCorrect, LetterTransposed}
-	NumPatterns        = int(math.Pow(float64(len(Hints)), WordLength))
-	AllPatterns        = generateAllPatterns()
-	SolutionTree       = assets.Load[*WordleTree](assets.SolutionTreeFile)
-	GuessTree          = assets.Load[*WordleTree](assets.GuessTreeFile)
-	GuessConstraintMap = assets.Load[ConstraintMap](assets.ConstraintMapFile)
-	// SolutionTree       = NewWordleTree(Wordles, NewConstraint())
-	// GuessTree          = NewWordleTree(WordlesAndNonWordles, NewConstraint())
-	// GuessConstraintMap = NewConstraintMap(WordlesAndNonWordles, SolutionTree)
+	Hints       = [3]Hint{LetterWrong, LetterCorrect, LetterTransposed}
+	allPatterns = generateAllPatternsByLength()
 )
 
 func NewLetterConstraint(char byte) LetterConstraint {
@@ -84,24 +96,29 @@ func (lc LetterConstraint) ToChars() []byte {
 }
 
 func NewConstraint() Constraint {
-	return Constraint{
-		Letters: [5]LetterConstraint{
-			LetterConstraint(math.MaxUint32),
-			LetterConstraint(math.MaxUint32),
-			LetterConstraint(math.MaxUint32),
-			LetterConstraint(math.MaxUint32),
-			LetterConstraint(math.MaxUint32),
-		},
-		Counts: [5]MinCountConstraint{},
+	c := Constraint{}
+	for i := range MaxWordLength {
+		c.Letters[i] = LetterConstraint(math.MaxUint32)
 	}
+
+	return c
 }
 
 func (c Constraint) And(other Constraint) Constraint {
-	combinedCounts := [5]MinCountConstraint(slices.Clone(c.Counts[:]))
+	combined := Constraint{Counts: c.Counts}
+	for i := range MaxWordLength {
+		combined.Letters[i] = c.Letters[i] & other.Letters[i]
+	}
+
+	combinedCounts := &combined.Counts
 	for _, counts := range other.Counts {
-		for i := range WordLength {
+		if counts.Char == 0 {
+			continue
+		}
+		for i := range MaxWordLength {
 			if counts.Char == combinedCounts[i].Char {
-				combinedCounts[i].Count = max(combinedCounts[i].Count, counts.Count)
+				combinedCounts[i].Min = max(combinedCounts[i].Min, counts.Min)
+				combinedCounts[i].Max = min(combinedCounts[i].Max, counts.Max)
 				break
 			}
 			if combinedCounts[i].Char == 0 {
@@ -111,30 +128,32 @@ func (c Constraint) And(other Constraint) Constraint {
 		}
 	}
 
-	return Constraint{
-		Letters: [5]LetterConstraint{
-			c.Letters[0] & other.Letters[0],
-			c.Letters[1] & other.Letters[1],
-			c.Letters[2] & other.Letters[2],
-			c.Letters[3] & other.Letters[3],
-			c.Letters[4] & other.Letters[4],
-		},
-		Counts: combinedCounts,
-	}
+	return combined
 }
 
-func (c Constraint) Dec(char byte) Constraint {
+// Consumes one occurrence of char, returns false if this
+// exceeds the maximum count of char.
+func (c Constraint) Dec(char byte) (Constraint, bool) {
 	for i := range len(c.Counts) {
-		if c.Counts[i].Char == char {
-			c.Counts[i].Count--
+		if c.Counts[i].Char != char {
+			continue
+		}
+		if c.Counts[i].Max == 0 {
+			return c, false
+		}
+		if c.Counts[i].Min > 0 {
+			c.Counts[i].Min--
+		}
+		if c.Counts[i].Max != UnboundedCount {
+			c.Counts[i].Max--
 		}
 	}
 
-	return c
+	return c, true
 }
 
 func (c Constraint) Matches(word string) bool {
-	for i := range WordLength {
+	for i := range len(word) {
 		letter := NewLetterConstraint(word[i])
 		if !c.Letters[i].Includes(letter) {
 			return false
@@ -142,14 +161,18 @@ func (c Constraint) Matches(word string) bool {
 	}
 
 	for _, countConstraint := range c.Counts {
+		if countConstraint.Char == 0 {
+			continue
+		}
+
 		count := byte(0)
-		for i := range WordLength {
+		for i := range len(word) {
 			if word[i] == countConstraint.Char {
 				count++
 			}
 		}
 
-		if count < countConstraint.Count {
+		if count < countConstraint.Min || count > countConstraint.Max {
 			return false
 		}
 	}
@@ -164,12 +187,14 @@ func NewWordleTreeNode() *WordleTreeNode {
 	}
 }
 
-func NewWordleTree(wordles []string, constraint Constraint) *WordleTree {
-	root := &WordleTree{WordleTreeNode: *NewWordleTreeNode()}
+// Builds a tree of the wordles matching the constraint, wordles
+// of a different length than wordLength are skipped.
+func NewWordleTree(wordLength int, wordles []string, constraint Constraint) *WordleTree {
+	root := &WordleTree{WordleTreeNode: *NewWordleTreeNode(), WordLength: wordLength}
 	var node *WordleTreeNode
 
 	for _, word := range wordles {
-		if !constraint.Matches(word) {
+		if len(word) != wordLength || !constraint.Matches(word) {
 			continue
 		}
 
@@ -177,7 +202,7 @@ func NewWordleTree(wordles []string, constraint Constraint) *WordleTree {
 		root.WordCount++
 		node = &root.WordleTreeNode
 
-		for i := range WordLength {
+		for i := range wordLength {
 			char := word[i]
 			if child, ok := node.Children[char]; ok {
 				node = child
@@ -193,14 +218,31 @@ func NewWordleTree(wordles []string, constraint Constraint) *WordleTree {
 	return root
 }
 
+func (wt *WordleTree) Contains(word string) bool {
+	if len(word) != wt.WordLength {
+		return false
+	}
+
+	node := &wt.WordleTreeNode
+	for i := range wt.WordLength {
+		child, ok := node.Children[word[i]]
+		if !ok {
+			return false
+		}
+		node = child
+	}
+
+	return true
+}
+
 func (wt *WordleTree) HasMatches(constraint Constraint) bool {
-	return hasMatches(&wt.WordleTreeNode, constraint, 0)
+	return hasMatches(&wt.WordleTreeNode, constraint, 0, wt.WordLength)
 }
 
-func hasMatches(node *WordleTreeNode, constraint Constraint, depth int) bool {
-	if depth >= WordLength {
+func hasMatches(node *WordleTreeNode, constraint Constraint, depth int, wordLength int) bool {
+	if depth >= wordLength {
 		for _, countConstraint := range constraint.Counts {
-			if countConstraint.Count > 0 {
+			if countConstraint.Min > 0 {
 				return false
 			}
 		}
@@ -209,7 +251,7 @@ func hasMatches(node *WordleTreeNode, constraint Constraint, depth int) bool {
 
 	options := node.Options & constraint.Letters[depth]
 	for _, char := range options.ToChars() {
-		if hasMatches(node.Children[char], constraint.Dec(char), depth+1) {
+		if next, ok := constraint.Dec(char); ok && hasMatches(node.Children[char], next, depth+1, wordLength) {
 			return true
 		}
 	}
@@ -218,13 +260,13 @@ func hasMatches(node *WordleTreeNode, constraint Constraint, depth int) bool {
 }
 
 func (wt *WordleTree) CountMatches(constraint Constraint) int {
-	return countMatches(&wt.WordleTreeNode, constraint, 0)
+	return countMatches(&wt.WordleTreeNode, constraint, 0, wt.WordLength)
 }
 
-func countMatches(node *WordleTreeNode, constraint Constraint, depth int) (matches int) {
-	if depth >= WordLength {
+func countMatches(node *WordleTreeNode, constraint Constraint, depth int, wordLength int) (matches int) {
+	if depth >= wordLength {
 		for _, countConstraint := range constraint.Counts {
-			if countConstraint.Count > 0 {
+			if countConstraint.Min > 0 {
 				return 0
 			}
 		}
@@ -233,73 +275,140 @@ func countMatches(node *WordleTreeNode, constraint Constraint, depth int) (match
 
 	options := node.Options & constraint.Letters[depth]
 	for _, char := range options.ToChars() {
-		matches += countMatches(node.Children[char], constraint.Dec(char), depth+1)
+		if next, ok := constraint.Dec(char); ok {
+			matches += countMatches(node.Children[char], next, depth+1, wordLength)
+		}
 	}
 
 	return matches
 }
 
-func (wt *WordleTree) utility(constraints []Constraint) (utility float64) {
-	wordCount := float64(wt.WordCount)
-
-	for _, constraint := range constraints {
-		matches := wt.CountMatches(constraint)
-		if matches > 0 {
-			p := float64(matches) / wordCount
-			// p * log(1/p) = p * -log(p)
-			utility -= p * math.Log2(p)
+// Checks that Wordle can yield the pattern for the word
+func checkPattern(word string, pattern Pattern) error {
+	for i := range len(word) {
+		if pattern[i] == LetterWrong {
+			for j := i + 1; j < len(word); j++ {
+				// Transpositions(Yellow) of the same letter may not
+				// occur after a wrong letter, they must be first.
+				if word[i] == word[j] && pattern[j] == LetterTransposed {
+					return fmt.Errorf("%s letter %c can not be yellow after the grey %s letter %c",
+						ordinal(j+1), word[j]-32, ordinal(i+1), word[i]-32)
+				}
+			}
 		}
 	}
 
-	return utility
+	return nil
 }
 
-func NewConstraintMap(wordles []string, worldeTree *WordleTree) ConstraintMap {
-	constraintMap := make(map[string][]Constraint, len(wordles))
-
-	for _, word := range wordles {
-		constraints := make([]Constraint, 0, NumPatterns)
+// Explains why none of the candidates matches both the previous constraint and the hints of the word
+func explainConflict(prev Constraint, word string, hints Pattern, candidates []string) error {
+	wordLength := len(word)
+	next := constraintFromPattern(word, hints)
+	combined := prev.And(next)
+	for i := range wordLength {
+		if len(combined.Letters[i].ToChars()) > 0 {
+			continue
+		}
+		// A correct letter before conflicts with any other hint, including another correct letter
+		if chars := prev.Letters[i].ToChars(); len(chars) == 1 {
+			return fmt.Errorf("%s letter must be %c, it was correct before", ordinal(i+1), chars[0]-32)
+		}
+		if chars := next.Letters[i].ToChars(); len(chars) == 1 {
+			return fmt.Errorf("%s letter can not be %c, it was ruled out before", ordinal(i+1), chars[0]-32)
+		}
+		return fmt.Errorf("No letter is possible as %s letter", ordinal(i+1))
+	}
 
-		for _, pattern := range AllPatterns {
-			if !isValidPattern(word, pattern) {
-				continue
-			}
+	minLetters := 0
+	for _, count := range combined.Counts {
+		if count.Char == 0 {
+			continue
+		}
+		if count.Min > count.Max {
+			return fmt.Errorf("%c must occur at least %s but at most %s", count.Char-32, times(count.Min), times(count.Max))
+		}
 
-			// Only include constraints with matches
-			c := constraintFromPattern(word, pattern)
-			if worldeTree.HasMatches(c) {
-				constraints = append(constraints, c)
+		positions := 0
+		for i := range wordLength {
+			if combined.Letters[i].Includes(NewLetterConstraint(count.Char)) {
+				positions++
 			}
 		}
-
-		constraintMap[word] = constraints
+		if positions == 0 {
+			return fmt.Errorf("%c must occur but was ruled out before", count.Char-32)
+		}
+		if positions < int(count.Min) {
+			return fmt.Errorf("%c must occur at least %s but fits only %d positions", count.Char-32, times(count.Min), positions)
+		}
+		minLetters += int(count.Min)
+	}
+	if minLetters > wordLength {
+		return fmt.Errorf("The hints require %d letters, more than the word length %d", minLetters, wordLength)
 	}
 
-	return constraintMap
+	return explainClosest(word, hints, candidates)
 }
 
-func isValidPattern(word string, pattern Pattern) bool {
-	for i := range WordLength {
-		if pattern[i] == LetterWrong {
-			for j := i + 1; j < WordLength; j++ {
-				// Transpositions(Yellow) of the same letter may not
-				// occur after a wrong letter, they must be first.
-				if word[i] == word[j] && pattern[j] == LetterTransposed {
-					return false
-				}
+// Names the letters of the word whose hints rule out the candidate closest to the hints
+func explainClosest(word string, hints Pattern, candidates []string) error {
+	var closest string
+	var closestHints Pattern
+	var differing []int
+	for _, candidate := range candidates {
+		candidateHints := Score(word, candidate)
+		var positions []int
+		for i := range candidateHints {
+			if candidateHints[i] != hints[i] {
+				positions = append(positions, i)
 			}
 		}
+		if closest == "" || len(positions) < len(differing) {
+			closest, closestHints, differing = candidate, candidateHints, positions
+		}
+	}
+	if closest == "" {
+		return fmt.Errorf("No remaining solution matches the hints")
 	}
 
-	return true
+	letters := make([]string, len(differing))
+	for i, position := range differing {
+		letters[i] = fmt.Sprintf("%s letter %c", ordinal(position+1), word[position]-32)
+	}
+	return fmt.Errorf("No remaining solution matches the hints, the closest %s would give %s for the %s",
+		strings.ToUpper(closest), closestHints, joinAnd(letters))
+}
+
+// Joins the items as e.g. 'a, b and c'
+func joinAnd(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+func times(n byte) string {
+	switch n {
+	case 0:
+		return "zero times"
+	case 1:
+		return "once"
+	case 2:
+		return "twice"
+	default:
+		return fmt.Sprintf("%d times", n)
+	}
 }
 
 func constraintFromPattern(word string, pattern Pattern) Constraint {
 	constraint := NewConstraint()
-	counts := make(map[byte]byte, WordLength)
+	counts := make(map[byte]byte, len(word))
+	// Letters with a wrong hint occur exactly as often as they are correct or transposed.
+	exact := make(map[byte]bool, len(word))
 
 	// Letter constraints
-	for i := range WordLength {
+	for i := range len(word) {
 		char := word[i]
 
 		switch pattern[i] {
@@ -311,6 +420,7 @@ func constraintFromPattern(word string, pattern Pattern) Constraint {
 			counts[char]++
 		case LetterWrong:
 			constraint.Letters[i] = constraint.Letters[i].Exclude(char)
+			exact[char] = true
 
 			var hasTransposedDuplicate bool
 			// Duplicate yellows can only occur before wrong letters.
@@ -320,7 +430,7 @@ func constraintFromPattern(word string, pattern Pattern) Constraint {
 			// A duplicate yellow of the same char means we
 			// cannot exclude 'char' from the rest of the pattern.
 			if !hasTransposedDuplicate {
-				for j := range WordLength {
+				for j := range len(word) {
 					// Only exclude in places which are not same char & not correct
 					if word[j] != char || pattern[j] != LetterCorrect {
 						constraint.Letters[j] = constraint.Letters[j].Exclude(char)
@@ -333,24 +443,87 @@ func constraintFromPattern(word string, pattern Pattern) Constraint {
 	// Count constraints
 	var i int
 	for char, count := range counts {
-		constraint.Counts[i] = MinCountConstraint{char, count}
+		maxCount := byte(UnboundedCount)
+		if exact[char] {
+			maxCount = count
+		}
+		constraint.Counts[i] = CountConstraint{char, count, maxCount}
+		i++
+	}
+
+	return constraint
+}
+
+// Constraint of the guesses allowed in hard mode, correct letters must stay
+// in place and the correct and transposed letters must all be used.
+func hardModeConstraintFromPattern(word string, pattern Pattern) Constraint {
+	constraint := NewConstraint()
+	counts := make(map[byte]byte, len(word))
+
+	for i := range len(word) {
+		char := word[i]
+
+		switch pattern[i] {
+		case LetterCorrect:
+			constraint.Letters[i] = NewLetterConstraint(char)
+			counts[char]++
+		case LetterTransposed:
+			counts[char]++
+		}
+	}
+
+	var i int
+	for char, count := range counts {
+		constraint.Counts[i] = CountConstraint{char, count, UnboundedCount}
 		i++
 	}
 
 	return constraint
 }
 
+// Number of patterns for words of the given length
+func NumPatterns(wordLength int) int {
+	return int(math.Pow(float64(len(Hints)), float64(wordLength)))
+}
+
+// All patterns for words of the given length
+func AllPatterns(wordLength int) []Pattern {
+	return allPatterns[wordLength]
+}
+
+func generateAllPatternsByLength() (patterns [MaxWordLength + 1][]Pattern) {
+	for wordLength := MinWordLength; wordLength <= MaxWordLength; wordLength++ {
+		patterns[wordLength] = generateAllPatterns(wordLength)
+	}
+
+	return patterns
+}
+
+// Index of the pattern in AllPatterns, i.e. the hints read as a base 3 number
+func patternIndex(pattern []Hint) int {
+	index := 0
+	for i := len(pattern) - 1; i >= 0; i-- {
+		index = index*len(Hints) + int(pattern[i]-LetterWrong)
+	}
+
+	return index
+}
+
 // Generates all patterns (i.e. permutations of hints)
-func generateAllPatterns() []Pattern {
-	patterns := make([]Pattern, NumPatterns)
-	p := Pattern{LetterWrong, LetterWrong, LetterWrong, LetterWrong, LetterWrong}
+func generateAllPatterns(wordLength int) []Pattern {
+	numPatterns := NumPatterns(wordLength)
+	patterns := make([]Pattern, numPatterns)
+	p := make(Pattern, wordLength)
+	for i := range p {
+		p[i] = LetterWrong
+	}
 
 	// Find all permutations by counting in base 3
-	for i := range NumPatterns {
-		patterns[i] = p
+	for i := range numPatterns {
+		patterns[i] = slices.Clone(p)
 		p[0]++
 
-		for j := range WordLength - 1 {
+		for j := range wordLength - 1 {
 			// Carry over
 			if p[j] > LetterCorrect {
 				p[j] = LetterWrong
@@ -363,22 +536,89 @@ func generateAllPatterns() []Pattern {
 }
 
 type Solver struct {
-	state         GameState
-	constraints   Constraint
-	solutionTree  *WordleTree
-	guessTree     *WordleTree
-	constraintMap map[string][]Constraint
-	numGuesses    int
+	state        GameState
+	constraints  Constraint
+	solutionTree *WordleTree
+	guessTree    *WordleTree
+	matrix       *PatternMatrix
+	numGuesses   int
+	wordLength   int
+	hardMode     bool
+	scorer       Scorer
+	// Weights of the solutions by their priors, nil if every solution is equally likely
+	weights map[string]float64
+	// Constraints on the guesses allowed in hard mode
+	hardConstraints Constraint
+	// Nil once the guesses deviate from the strategy
+	strategyNode *StrategyNode
+	// The solver before each guess, restored by Rewind
+	history []Solver
+	// Shared by copies of the solver, replaced whenever a guess is added
+	ranking *ranking
+}
+
+// Cached ranking of all guesses, nil until the first complete ranking. A ranking
+// interrupted by its context keeps the scored guesses, the next one continues from them.
+type ranking struct {
+	// Held while scoring, a channel to stop waiting once the context is done
+	lock      chan struct{}
+	utilities []WordUtility
+	partial   []WordUtility
+}
+
+func newRanking() *ranking {
+	return &ranking{lock: make(chan struct{}, 1)}
 }
 
-func NewSolver() Solver {
+func NewSolver(opts ...Option) (Solver, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return Solver{}, err
+	}
+	state, err := NewGameState(opts...)
+	if err != nil {
+		return Solver{}, err
+	}
+	matrix := o.dictionary.getPatternMatrix()
+
+	strategy := o.strategy
+	if strategy == nil {
+		strategy = o.dictionary.strategy
+	}
+	var strategyNode *StrategyNode
+	if strategy != nil && strategy.WordLength == o.dictionary.WordLength && !o.hardMode {
+		strategyNode = strategy.Root
+	}
+
+	var weights map[string]float64
+	if o.priors != nil {
+		weights = o.priors.weights(o.dictionary.Wordles)
+	}
+
 	return Solver{
-		state:         NewGameState(),
-		constraints:   NewConstraint(),
-		solutionTree:  SolutionTree,
-		guessTree:     GuessTree,
-		constraintMap: GuessConstraintMap,
+		state:           state,
+		constraints:     NewConstraint(),
+		solutionTree:    o.dictionary.solutionTree,
+		guessTree:       o.dictionary.guessTree,
+		matrix:          matrix,
+		wordLength:      o.dictionary.WordLength,
+		hardMode:        o.hardMode,
+		scorer:          o.scorer,
+		weights:         weights,
+		hardConstraints: NewConstraint(),
+		strategyNode:    strategyNode,
+		ranking:         newRanking(),
+	}, nil
+}
+
+// The next guess of the precomputed strategy, false if there is no strategy
+// or the previous guesses deviated from it.
+func (s *Solver) StrategyGuess() (string, bool) {
+	if s.strategyNode == nil {
+		return "", false
 	}
+
+	return s.strategyNode.Guess, true
 }
 
 // Pad string with spaces, takes into account unprintable ANSI control sequences
@@ -411,86 +651,375 @@ func padRightLines(lines []string) {
 
 func (s Solver) String() string {
 	lines := []string{
-		fmt.Sprintf("%sUncertainty: %.3f bits", ansiUnderlined, math.Log2(float64(s.solutionTree.WordCount))),
+		fmt.Sprintf("%sUncertainty: %.3f bits", ansiUnderlined, s.Entropy()),
 		"",
 	}
+	if guess, ok := s.StrategyGuess(); ok {
+		lines[1] = "Strategy: " + guess
+	}
 	lines = append(lines, strings.Split(s.state.String(), "\n")...)
 	padRightLines(lines)
 
-	lines[0] += "  |  Expected utility in bits     " + ansiNotUnderlined
-	lines[1] += "  |                |"
 	numCols := 2
 	tableLines := lines[2:]
-	utilities := s.topNWords(len(tableLines) * numCols)
-	for i := range tableLines {
-		if i < len(utilities) {
-			tableLines[i] = fmt.Sprintf("  %s|  %s  %.3f", tableLines[i], utilities[i].Word, utilities[i].Utility)
+	utilities := s.Suggestions(len(tableLines) * numCols)
+	values := make([]string, len(tableLines)*numCols)
+	valueWidth := 5
+	for i, utility := range utilities {
+		values[i] = fmt.Sprintf("%.3f", utility.Utility)
+		valueWidth = max(valueWidth, len(values[i]))
+	}
+	colWidth := s.wordLength + valueWidth + 7
+
+	lines[0] += fmt.Sprintf("  |  %-*s", numCols*colWidth-5, s.scorer) + ansiNotUnderlined
+	lines[1] += fmt.Sprintf("  |  %-*s", numCols*colWidth-5, "* is a possible answer")
+	// Possible answers are marked with an asterisk
+	words := make([]string, len(values))
+	for i := range words {
+		if i >= len(utilities) {
+			words[i] = strings.Repeat(" ", s.wordLength+1)
+		} else if utilities[i].Probability > 0 {
+			words[i] = utilities[i].Word + "*"
 		} else {
-			tableLines[i] = fmt.Sprintf("  %s|  %s  %s", tableLines[i], "     ", "     ")
+			words[i] = utilities[i].Word + " "
 		}
 	}
+	for i := range tableLines {
+		tableLines[i] = fmt.Sprintf("  %s|  %s %*s", tableLines[i], words[i], valueWidth, values[i])
+	}
 	for i := len(tableLines); i < len(tableLines)*2; i++ {
-		if i < len(utilities) {
-			tableLines[i%len(tableLines)] += fmt.Sprintf("  |  %s  %.3f", utilities[i].Word, utilities[i].Utility)
-		} else {
-			tableLines[i%len(tableLines)] += fmt.Sprintf("  |  %s  %s", "     ", "     ")
-		}
+		tableLines[i%len(tableLines)] += fmt.Sprintf("  |  %s %*s", words[i], valueWidth, values[i])
 	}
 
 	return strings.Join(lines, "\n")
 }
 
-func (s *Solver) AddGuess(word string, hints [5]Hint) (bool, error) {
-	if s.numGuesses >= MaxGuesses {
-		return true, nil
+// Adds a guess with its hints, returns whether the word is solved or the guesses ran out.
+// Guesses after that are rejected with ErrGameOver.
+func (s *Solver) AddGuess(word string, hints Pattern) (bool, error) {
+	if s.state.solved() {
+		return false, fmt.Errorf("%w, the word has been guessed", ErrGameOver)
 	}
 
+	prev := *s
 	if err := s.state.AddGuess(word, hints); err != nil {
 		return false, err
 	}
-
-	allCorrect := true
-	for _, s := range hints {
-		allCorrect = allCorrect && s == LetterCorrect
+	// Contradictions are rejected without changing the solver
+	if err := checkPattern(word, hints); err != nil {
+		*s = prev
+		return false, err
 	}
-	if allCorrect {
-		return true, nil
+	next := constraintFromPattern(word, hints)
+	constraints := s.constraints.And(next)
+	solutionTree := NewWordleTree(s.wordLength, s.solutionTree.Wordles, constraints)
+	if solutionTree.WordCount == 0 {
+		*s = prev
+		return false, explainConflict(s.constraints, word, hints, s.solutionTree.Wordles)
 	}
+	s.history = append(s.history, prev)
+	s.ranking = newRanking()
 
-	s.constraints = s.constraints.And(constraintFromPattern(word, Pattern(hints)))
-	s.solutionTree = NewWordleTree(s.solutionTree.Wordles, s.constraints)
-	s.guessTree = NewWordleTree(s.guessTree.Wordles, s.constraints)
-	s.constraintMap = NewConstraintMap(s.guessTree.Wordles, s.solutionTree)
+	if guess, ok := s.StrategyGuess(); ok && guess == word {
+		s.strategyNode = s.strategyNode.Next(hints)
+	} else {
+		s.strategyNode = nil
+	}
+
+	// Solved guesses leave the guessed word as the only candidate
+	s.constraints = constraints
+	s.solutionTree = solutionTree
+	// Only guesses consistent with the hints are ranked, hard mode
+	// allows any guess using the revealed hints instead.
+	if s.hardMode {
+		s.hardConstraints = s.hardConstraints.And(hardModeConstraintFromPattern(word, hints))
+		s.guessTree = NewWordleTree(s.wordLength, s.guessTree.Wordles, s.hardConstraints)
+	} else {
+		s.guessTree = NewWordleTree(s.wordLength, s.guessTree.Wordles, s.constraints)
+	}
 	s.numGuesses++
-	return s.numGuesses >= MaxGuesses, nil
+	return hints.Solved() || s.numGuesses >= s.state.maxGuesses, nil
+}
+
+// Removes the last guess, restoring the candidates from before it
+func (s *Solver) Undo() error {
+	if len(s.history) == 0 {
+		return fmt.Errorf("No guesses to undo")
+	}
+
+	return s.Rewind(len(s.history) - 1)
+}
+
+// Removes all guesses after the first n
+func (s *Solver) Rewind(n int) error {
+	if n < 0 || n > len(s.history) {
+		return fmt.Errorf("Invalid number of guesses %d, should be between 0 and %d", n, len(s.history))
+	}
+	if n < len(s.history) {
+		*s = s.history[n]
+	}
+
+	return nil
+}
+
+// The n guesses with the highest utility according to the scorer
+func (s *Solver) Suggestions(n int) []WordUtility {
+	utilities, _ := s.SuggestionsContext(context.Background(), n)
+	return utilities
+}
+
+// Like Suggestions, but stops scoring once the context is done. The best of the
+// guesses scored until then are returned together with the context's error, the
+// next call continues scoring the rest. Waiting for a concurrent call stops as well.
+func (s *Solver) SuggestionsContext(ctx context.Context, n int) ([]WordUtility, error) {
+	utilities, err := s.rankGuesses(ctx)
+	n = min(n, len(utilities))
+	return slices.Clone(utilities[:n]), err
+}
+
+// The guesses added so far
+func (s *Solver) Guesses() []*Guess {
+	return s.state.Guesses()
+}
+
+// The remaining possible solutions, ordered from most to least likely if there are priors
+func (s *Solver) Candidates() []string {
+	candidates := slices.Clone(s.solutionTree.Wordles)
+	if s.weights != nil {
+		slices.SortStableFunc(candidates, func(a string, b string) int {
+			return cmp.Compare(s.weights[b], s.weights[a])
+		})
+	}
+
+	return candidates
+}
+
+// The entropy of the remaining possible solutions in bits
+func (s *Solver) Entropy() float64 {
+	if s.weights == nil {
+		return math.Log2(float64(s.solutionTree.WordCount))
+	}
+
+	weights := make([]float64, len(s.solutionTree.Wordles))
+	for i, word := range s.solutionTree.Wordles {
+		weights[i] = s.weights[word]
+	}
+
+	return entropy(weights, s.totalWeight())
+}
+
+// The probability that word is the solution, given the remaining possible solutions
+func (s *Solver) AnswerProbability(word string) float64 {
+	if !s.solutionTree.Contains(word) {
+		return 0
+	}
+	if s.weights == nil {
+		return 1 / float64(s.solutionTree.WordCount)
+	}
+
+	return s.weights[word] / s.totalWeight()
+}
+
+// Weight of a remaining possible solution
+func (s *Solver) weight(word string) float64 {
+	if s.weights == nil {
+		return 1
+	}
+
+	return s.weights[word]
+}
+
+// Sum of the weights of the remaining possible solutions
+func (s *Solver) totalWeight() (total float64) {
+	if s.weights == nil {
+		return float64(s.solutionTree.WordCount)
+	}
+	for _, word := range s.solutionTree.Wordles {
+		total += s.weights[word]
+	}
+
+	return total
+}
+
+// The probability of each pattern the guess can yield, given the remaining
+// possible solutions, ordered from most to least likely.
+func (s *Solver) PatternProbabilities(guess string) ([]PatternProbability, error) {
+	if err := s.state.dictionary.CheckWord(guess); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int)
+	weights := make(map[int]float64)
+	hints := make(Pattern, s.wordLength)
+	for _, candidate := range s.solutionTree.Wordles {
+		scoreInto(guess, candidate, hints)
+		counts[patternIndex(hints)]++
+		weights[patternIndex(hints)] += s.weight(candidate)
+	}
+
+	total := s.totalWeight()
+	patterns := AllPatterns(s.wordLength)
+	probabilities := make([]PatternProbability, 0, len(counts))
+	for index, count := range counts {
+		probabilities = append(probabilities, PatternProbability{
+			Pattern:     slices.Clone(patterns[index]),
+			Count:       count,
+			Probability: weights[index] / total,
+		})
+	}
+
+	sort.Slice(probabilities, func(i int, j int) bool {
+		if probabilities[i].Probability == probabilities[j].Probability {
+			return patternIndex(probabilities[i].Pattern) < patternIndex(probabilities[j].Pattern)
+		}
+		return probabilities[i].Probability > probabilities[j].Probability
+	})
+
+	return probabilities, nil
+}
+
+// The guess with the highest utility, empty if there are no guesses left
+func (s *Solver) BestGuess() string {
+	if utilities := s.Suggestions(1); len(utilities) > 0 {
+		return utilities[0].Word
+	}
+
+	return ""
 }
 
-func (s *Solver) topNWords(n int) []WordUtility {
+// Scores and sorts all guesses, complete rankings are cached until the next guess
+func (s *Solver) rankGuesses(ctx context.Context) ([]WordUtility, error) {
+	select {
+	case s.ranking.lock <- struct{}{}:
+		defer func() { <-s.ranking.lock }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if s.ranking.utilities != nil {
+		return s.ranking.utilities, nil
+	}
+
 	if s.solutionTree.WordCount == 1 {
-		return []WordUtility{{s.solutionTree.Wordles[0], 0.0}}
+		solved := s.totalWeight()
+		p := Partition{Counts: []float64{solved}, Total: solved, Solutions: 1, Solved: solved}
+		s.ranking.utilities = []WordUtility{{Word: s.solutionTree.Wordles[0], Utility: s.scorer.Score(p), Probability: 1.0}}
+		return s.ranking.utilities, nil
+	}
+
+	// Only the guesses not scored by an interrupted ranking are left
+	guesses := s.guessTree.Wordles
+	if len(s.ranking.partial) > 0 {
+		scored := make(map[string]bool, len(s.ranking.partial))
+		for _, utility := range s.ranking.partial {
+			scored[utility.Word] = true
+		}
+		guesses = slices.DeleteFunc(slices.Clone(guesses), func(guess string) bool {
+			return scored[guess]
+		})
+	}
+
+	columns, weights := s.columns()
+	utilities, err := scoreGuesses(ctx, s.matrix, guesses, func(row MatrixRow, counts []float64) float64 {
+		return s.utility(row, columns, weights, counts)
+	})
+	utilities = append(s.ranking.partial, utilities...)
+
+	total := s.totalWeight()
+	for i := range utilities {
+		if s.solutionTree.Contains(utilities[i].Word) {
+			utilities[i].Probability = s.weight(utilities[i].Word) / total
+		}
 	}
 
-	utilities := make([]WordUtility, len(s.guessTree.Wordles))
+	// Prefer likely solutions among equally useful guesses
+	sort.Slice(utilities, func(i int, j int) bool {
+		if utilities[i].Utility == utilities[j].Utility {
+			return utilities[i].Probability > utilities[j].Probability
+		}
+		return ranksBefore(s.scorer, utilities[i].Utility, utilities[j].Utility)
+	})
+
+	if err == nil {
+		s.ranking.utilities, s.ranking.partial = utilities, nil
+	} else {
+		s.ranking.partial = utilities
+	}
+	return utilities, err
+}
+
+// Columns and weights of the remaining solutions in the pattern matrix
+func (s *Solver) columns() (columns []int, weights []float64) {
+	columns = make([]int, 0, s.solutionTree.WordCount)
+	weights = make([]float64, 0, s.solutionTree.WordCount)
+	for _, word := range s.solutionTree.Wordles {
+		if column, ok := s.matrix.column(word); ok {
+			columns = append(columns, column)
+			weights = append(weights, s.weight(word))
+		}
+	}
+
+	return columns, weights
+}
 
-	maxRoutines := 1000
-	sem := make(chan struct{}, maxRoutines)
+// Scores the guesses by their rows of the pattern matrix, guesses without a row score 0.
+// Workers take the next guess until all are scored or the context is done, in which case
+// only the scored guesses are returned together with the context's error. Score is given
+// a buffer of a count per pattern.
+func scoreGuesses(ctx context.Context, matrix *PatternMatrix, guesses []string, score func(row MatrixRow, counts []float64) float64) ([]WordUtility, error) {
+	utilities := make([]WordUtility, len(guesses))
+	scored := make([]bool, len(guesses))
+
+	var next atomic.Int64
+	numWorkers := runtime.GOMAXPROCS(0)
 	var wg sync.WaitGroup
-	for i, word := range s.guessTree.Wordles {
+	for range numWorkers {
 		wg.Add(1)
-		sem <- struct{}{}
-		go func(i int, word string) {
+		go func() {
 			defer wg.Done()
-			defer func() { <-sem }()
-			utilities[i].Word = word
-			utilities[i].Utility = s.solutionTree.utility(s.constraintMap[word])
-		}(i, word)
+			counts := make([]float64, NumPatterns(matrix.WordLength))
+			for ctx.Err() == nil {
+				i := int(next.Add(1)) - 1
+				if i >= len(guesses) {
+					return
+				}
+
+				utilities[i].Word = guesses[i]
+				if row, ok := matrix.GuessRow(guesses[i]); ok {
+					utilities[i].Utility = score(row, counts)
+				}
+				scored[i] = true
+			}
+		}()
 	}
 	wg.Wait()
 
-	sort.Slice(utilities, func(i int, j int) bool {
-		return utilities[i].Utility > utilities[j].Utility
-	})
+	if !slices.Contains(scored, false) {
+		return utilities, nil
+	}
 
-	n = min(n, len(utilities))
-	return utilities[:n]
+	partial := make([]WordUtility, 0, len(utilities))
+	for i, utility := range utilities {
+		if scored[i] {
+			partial = append(partial, utility)
+		}
+	}
+
+	return partial, ctx.Err()
+}
+
+// Scores a guess by the remaining solutions of each pattern, given its row of the pattern
+// matrix. Counts is a buffer of a count per pattern, it is cleared before counting.
+func (s *Solver) utility(row MatrixRow, columns []int, weights []float64, counts []float64) float64 {
+	clear(counts)
+	row.count(columns, weights, counts)
+
+	p := Partition{Solutions: len(columns), Solved: counts[len(counts)-1]}
+	for _, count := range counts {
+		if count > 0 {
+			p.Counts = append(p.Counts, count)
+			p.Total += count
+		}
+	}
+
+	return s.scorer.Score(p)
 }
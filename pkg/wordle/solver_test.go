@@ -0,0 +1,172 @@
+package wordle
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Words with repeated letters, the constraints of their hints must match exactly the words Score agrees on
+var duplicateWords = []string{
+	"speed", "abide", "eerie", "geese", "there", "abbey", "kebab", "llama", "hello",
+	"sassy", "grass", "steed", "deter", "elder", "added", "dodge", "mamma", "madam",
+	"array", "error", "lolly", "allay", "eject", "tepee", "emcee", "crane",
+}
+
+func TestConstraintFromPattern(t *testing.T) {
+	tests := []struct {
+		guess   string
+		answer  string
+		matches []string
+		rejects []string
+	}{
+		// One E is transposed and the other wrong, so E occurs exactly once
+		{"speed", "abide", []string{"abide", "bride", "oxide"}, []string{"eerie", "speed", "deter", "elder"}},
+		// Two Es are transposed and the third wrong, exactly two Es
+		{"eerie", "steed", []string{"steed", "speed", "tweed"}, []string{"eerie", "geese", "freed"}},
+		{"geese", "eerie", []string{"eerie"}, []string{"geese", "there", "emcee"}},
+		{"abbey", "kebab", []string{"kebab"}, []string{"abbey", "babes"}},
+		// No wrong L, so L occurs at least twice
+		{"llama", "hello", []string{"hello", "jello", "jelly"}, []string{"llama", "allay", "lolly"}},
+		{"sassy", "grass", []string{"grass", "brass", "class"}, []string{"sassy", "gross"}},
+		{"mamma", "madam", []string{"madam"}, []string{"mamma", "magma"}},
+	}
+
+	for _, test := range tests {
+		constraint := constraintFromPattern(test.guess, Score(test.guess, test.answer))
+		for _, word := range test.matches {
+			if !constraint.Matches(word) {
+				t.Errorf("%s for %s: expected %s to match", test.guess, test.answer, word)
+			}
+		}
+		for _, word := range test.rejects {
+			if constraint.Matches(word) {
+				t.Errorf("%s for %s: expected %s to be rejected", test.guess, test.answer, word)
+			}
+		}
+	}
+}
+
+func TestConstraintFromPatternAgreesWithScore(t *testing.T) {
+	for _, guess := range duplicateWords {
+		for _, answer := range duplicateWords {
+			hints := Score(guess, answer)
+			constraint := constraintFromPattern(guess, hints)
+			for _, word := range duplicateWords {
+				expected := slices.Equal(Score(guess, word), hints)
+				if constraint.Matches(word) != expected {
+					t.Errorf("%s for %s (%s): expected match of %s to be %t", guess, answer, hints, word, expected)
+				}
+			}
+		}
+	}
+}
+
+func TestConstraintDec(t *testing.T) {
+	c := NewConstraint()
+	c.Counts[0] = CountConstraint{'e', 1, 2}
+	c.Counts[1] = CountConstraint{'d', 1, UnboundedCount}
+	c.Counts[2] = CountConstraint{'s', 0, 0}
+
+	tests := []struct {
+		chars    string
+		ok       bool
+		expected [3]CountConstraint
+	}{
+		{"e", true, [3]CountConstraint{{'e', 0, 1}, {'d', 1, UnboundedCount}, {'s', 0, 0}}},
+		{"ee", true, [3]CountConstraint{{'e', 0, 0}, {'d', 1, UnboundedCount}, {'s', 0, 0}}},
+		{"eee", false, [3]CountConstraint{{'e', 0, 0}, {'d', 1, UnboundedCount}, {'s', 0, 0}}},
+		{"dd", true, [3]CountConstraint{{'e', 1, 2}, {'d', 0, UnboundedCount}, {'s', 0, 0}}},
+		{"s", false, [3]CountConstraint{{'e', 1, 2}, {'d', 1, UnboundedCount}, {'s', 0, 0}}},
+		// Letters without a count are unconstrained
+		{"a", true, [3]CountConstraint{{'e', 1, 2}, {'d', 1, UnboundedCount}, {'s', 0, 0}}},
+	}
+
+	for _, test := range tests {
+		next, ok := c, true
+		for i := range len(test.chars) {
+			if next, ok = next.Dec(test.chars[i]); !ok {
+				break
+			}
+		}
+		if ok != test.ok {
+			t.Errorf("Dec %s: expected ok %t, got %t", test.chars, test.ok, ok)
+		}
+		if counts := [3]CountConstraint(next.Counts[:3]); counts != test.expected {
+			t.Errorf("Dec %s: expected counts %v, got %v", test.chars, test.expected, counts)
+		}
+	}
+}
+
+func TestSolverSolvedGuess(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := solver.AddGuess("speed", Score("speed", "abide")); err != nil {
+		t.Fatal(err)
+	}
+	done, err := solver.AddGuess("abide", Score("abide", "abide"))
+	if err != nil || !done {
+		t.Fatalf("Expected the guess to solve the word, got %t %v", done, err)
+	}
+
+	if candidates := solver.Candidates(); !slices.Equal(candidates, []string{"abide"}) {
+		t.Errorf("Expected only abide to remain, got %d candidates", len(candidates))
+	}
+	if entropy := solver.Entropy(); entropy != 0 {
+		t.Errorf("Expected no uncertainty, got %.3f bits", entropy)
+	}
+	if guess := solver.BestGuess(); guess != "abide" {
+		t.Errorf("Expected abide to be suggested, got %s", guess)
+	}
+	if _, err := solver.AddGuess("crane", Score("crane", "abide")); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Expected %v, got %v", ErrGameOver, err)
+	}
+}
+
+func TestSolverRankingWaitsForContext(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another ranking of the same solver holds the lock
+	solver.ranking.lock <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := solver.SuggestionsContext(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v while waiting, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestSolverRankingResumesPartial(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := solver.Suggestions(solver.guessTree.WordCount)
+
+	// An interrupted ranking scored crane, it is not scored again
+	solver.ranking = newRanking()
+	solver.ranking.partial = []WordUtility{{Word: "crane", Utility: 100}}
+	utilities, err := solver.rankGuesses(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(utilities) != len(expected) || utilities[0].Word != "crane" || utilities[0].Utility != 100 {
+		t.Fatalf("Expected the partial crane to lead %d guesses, got %d led by %v", len(expected), len(utilities), utilities[0])
+	}
+	// Ties may be ordered differently, the utilities are compared by word
+	isCrane := func(u WordUtility) bool { return u.Word == "crane" }
+	byWord := func(a WordUtility, b WordUtility) int { return strings.Compare(a.Word, b.Word) }
+	rest, expectedRest := slices.DeleteFunc(utilities, isCrane), slices.DeleteFunc(expected, isCrane)
+	slices.SortFunc(rest, byWord)
+	slices.SortFunc(expectedRest, byWord)
+	if !slices.Equal(rest, expectedRest) {
+		t.Errorf("Expected the other guesses to be scored as without the partial ranking")
+	}
+}
@@ -0,0 +1,271 @@
+package wordle
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/Backshifted/wordle-solver/assets"
+)
+
+// Version of the Strategy format, strategies of other versions are not loaded.
+const StrategyVersion = 1
+
+type (
+	// A decision tree of guesses, each node holds the guess to make
+	// and the node to continue with for each possible pattern.
+	Strategy struct {
+		Version    int
+		WordLength int
+		Root       *StrategyNode
+	}
+	StrategyNode struct {
+		Guess string
+		// Whether the guess is one of the remaining solutions
+		IsSolution bool
+		// Keyed by pattern index, the solved pattern has no child
+		Children map[uint16]*StrategyNode
+	}
+	StrategyStats struct {
+		Solutions int
+		// Average number of guesses per solution
+		Average float64
+		Worst   int
+		// Number of solutions by number of guesses
+		Histogram map[int]int
+	}
+	StrategyOptions struct {
+		// Number of best guesses by utility to look ahead for at each node, at least 1
+		Breadth int
+		// Optional first guess, skips the search at the root
+		FirstGuess string
+	}
+	strategyBuilder struct {
+		guesses     []string
+		isSolution  map[string]bool
+		wordLength  int
+		numPatterns int
+		breadth     int
+	}
+)
+
+// Builds a strategy for the dictionary, at each node the best guesses by
+// utility are searched for the one that minimizes the expected number of guesses.
+func BuildStrategy(dictionary *Dictionary, opts StrategyOptions) (*Strategy, error) {
+	if opts.FirstGuess != "" && !dictionary.IsValid(opts.FirstGuess) {
+		return nil, fmt.Errorf("Not a valid first guess '%s'", opts.FirstGuess)
+	}
+
+	b := strategyBuilder{
+		guesses:     dictionary.guessTree.Wordles,
+		isSolution:  make(map[string]bool, len(dictionary.Wordles)),
+		wordLength:  dictionary.WordLength,
+		numPatterns: NumPatterns(dictionary.WordLength),
+		breadth:     max(opts.Breadth, 1),
+	}
+	for _, word := range dictionary.solutionTree.Wordles {
+		b.isSolution[word] = true
+	}
+
+	var root *StrategyNode
+	if opts.FirstGuess != "" {
+		root, _ = b.buildGuess(dictionary.solutionTree.Wordles, opts.FirstGuess, math.MaxInt)
+	} else {
+		root, _ = b.build(dictionary.solutionTree.Wordles, math.MaxInt)
+	}
+
+	return &Strategy{
+		Version:    StrategyVersion,
+		WordLength: dictionary.WordLength,
+		Root:       root,
+	}, nil
+}
+
+// Builds the best node for the candidates, returns the node and the total number of
+// guesses to solve every candidate starting with this node. The search is abandoned
+// once the cost reaches bound, in which case the cost is math.MaxInt.
+func (b *strategyBuilder) build(candidates []string, bound int) (*StrategyNode, int) {
+	if minCost := 2*len(candidates) - 1; bound != math.MaxInt && minCost >= bound {
+		return nil, math.MaxInt
+	}
+
+	switch len(candidates) {
+	case 1:
+		return &StrategyNode{Guess: candidates[0], IsSolution: true}, 1
+	case 2:
+		// Guessing either candidate is optimal, one takes a guess, the other two.
+		return &StrategyNode{
+			Guess:      candidates[0],
+			IsSolution: true,
+			Children: map[uint16]*StrategyNode{
+				b.patternIndex(candidates[0], candidates[1]): {Guess: candidates[1], IsSolution: true},
+			},
+		}, 3
+	}
+
+	var bestNode *StrategyNode
+	bestCost := math.MaxInt
+	for _, guess := range b.rankGuesses(candidates) {
+		if node, cost := b.buildGuess(candidates, guess, min(bound, bestCost)); cost < bestCost {
+			bestNode, bestCost = node, cost
+		}
+	}
+
+	return bestNode, bestCost
+}
+
+// Builds the node of a fixed guess, see build.
+func (b *strategyBuilder) buildGuess(candidates []string, guess string, bound int) (*StrategyNode, int) {
+	buckets := b.partition(candidates, guess)
+	solved := uint16(b.numPatterns - 1)
+	// Every candidate takes this guess, unsolved candidates take at least one more.
+	cost := 2*len(candidates) - len(buckets[solved])
+	if cost >= bound {
+		return nil, math.MaxInt
+	}
+
+	node := &StrategyNode{
+		Guess:      guess,
+		IsSolution: len(buckets[solved]) > 0,
+		Children:   make(map[uint16]*StrategyNode, len(buckets)),
+	}
+	for index, bucket := range buckets {
+		if index == solved {
+			continue
+		}
+
+		// Replace the lower bound of this bucket by its cost
+		child, childCost := b.build(bucket, bound-cost+len(bucket))
+		if childCost == math.MaxInt {
+			return nil, math.MaxInt
+		}
+		cost += childCost - len(bucket)
+		node.Children[index] = child
+	}
+
+	return node, cost
+}
+
+func (b *strategyBuilder) partition(candidates []string, guess string) map[uint16][]string {
+	buckets := make(map[uint16][]string)
+	for _, candidate := range candidates {
+		index := b.patternIndex(guess, candidate)
+		buckets[index] = append(buckets[index], candidate)
+	}
+
+	return buckets
+}
+
+func (b *strategyBuilder) patternIndex(guess string, answer string) uint16 {
+	var hints [MaxWordLength]Hint
+	scoreInto(guess, answer, hints[:b.wordLength])
+	return uint16(patternIndex(hints[:b.wordLength]))
+}
+
+// Best guesses by expected information, possible solutions break ties. Guesses
+// without information are excluded, as these do not reduce the candidates.
+func (b *strategyBuilder) rankGuesses(candidates []string) []string {
+	utilities := make([]WordUtility, len(b.guesses))
+
+	numWorkers := runtime.GOMAXPROCS(0)
+	var wg sync.WaitGroup
+	for worker := range numWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counts := make([]int, b.numPatterns)
+			for i := worker; i < len(b.guesses); i += numWorkers {
+				clear(counts)
+				for _, candidate := range candidates {
+					counts[b.patternIndex(b.guesses[i], candidate)]++
+				}
+
+				utilities[i] = WordUtility{Word: b.guesses[i], Utility: entropy(counts, len(candidates))}
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(utilities, func(i int, j int) bool {
+		if utilities[i].Utility == utilities[j].Utility {
+			return b.isSolution[utilities[i].Word] && !b.isSolution[utilities[j].Word]
+		}
+		return utilities[i].Utility > utilities[j].Utility
+	})
+
+	ranked := make([]string, 0, b.breadth)
+	for i := 0; i < len(utilities) && len(ranked) < b.breadth && utilities[i].Utility > 0; i++ {
+		ranked = append(ranked, utilities[i].Word)
+	}
+
+	return ranked
+}
+
+// Shannon entropy of the counts or weights
+func entropy[T int | float64](counts []T, total T) (utility float64) {
+	for _, count := range counts {
+		if count > 0 {
+			p := float64(count) / float64(total)
+			utility -= p * math.Log2(p)
+		}
+	}
+
+	return utility
+}
+
+// Next node after guessing the node's guess and receiving the hints,
+// nil if the hints solve the word or are impossible within the strategy.
+func (n *StrategyNode) Next(hints Pattern) *StrategyNode {
+	if n == nil || len(hints) != len(n.Guess) {
+		return nil
+	}
+
+	return n.Children[uint16(patternIndex(hints))]
+}
+
+// Computes the number of guesses the strategy takes for each solution
+func (s *Strategy) Stats() StrategyStats {
+	stats := StrategyStats{Histogram: make(map[int]int)}
+
+	total := 0
+	var walk func(node *StrategyNode, depth int)
+	walk = func(node *StrategyNode, depth int) {
+		if node.IsSolution {
+			stats.Histogram[depth]++
+			stats.Solutions++
+			stats.Worst = max(stats.Worst, depth)
+			total += depth
+		}
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+	}
+	walk(s.Root, 1)
+
+	if stats.Solutions > 0 {
+		stats.Average = float64(total) / float64(stats.Solutions)
+	}
+
+	return stats
+}
+
+// Loads a strategy written by cmd/precompute
+func LoadStrategy(path string) (*Strategy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read strategy '%s': %w", path, err)
+	}
+
+	strategy, err := assets.Load[*Strategy](data)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load strategy '%s': %w", path, err)
+	}
+	if strategy.Version != StrategyVersion {
+		return nil, fmt.Errorf("Unsupported strategy version %d, should be %d", strategy.Version, StrategyVersion)
+	}
+
+	return strategy, nil
+}
@@ -0,0 +1,107 @@
+package wordle
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+var (
+	// Words sharing all but a letter, which takes lookahead to solve in few guesses
+	strategyWordles = []string{
+		"batch", "catch", "hatch", "latch", "match", "patch", "watch",
+		"bound", "found", "hound", "mound", "pound", "round", "sound", "wound",
+		"fight", "light", "might", "night", "right", "sight", "tight",
+	}
+	strategyNonWordles = []string{"chomp", "blimp", "whelp", "frown", "dumpy"}
+)
+
+// The fewest total guesses to solve every candidate, trying every guess at every node
+func exhaustiveCost(guesses []string, candidates []string, costs map[string]int) int {
+	if len(candidates) == 1 {
+		return 1
+	}
+	key := strings.Join(candidates, ",")
+	if cost, ok := costs[key]; ok {
+		return cost
+	}
+
+	best := math.MaxInt
+	for _, guess := range guesses {
+		buckets := make(map[int][]string)
+		hints := make([]Hint, len(guess))
+		for _, candidate := range candidates {
+			scoreInto(guess, candidate, hints)
+			buckets[patternIndex(hints)] = append(buckets[patternIndex(hints)], candidate)
+		}
+		// Guesses without information do not get closer to a solution
+		if len(buckets) == 1 && len(candidates) > 1 {
+			if _, solved := buckets[NumPatterns(len(guess))-1]; !solved {
+				continue
+			}
+		}
+
+		cost := len(candidates)
+		for index, bucket := range buckets {
+			if index != NumPatterns(len(guess))-1 {
+				cost += exhaustiveCost(guesses, bucket, costs)
+			}
+		}
+		best = min(best, cost)
+	}
+
+	costs[key] = best
+	return best
+}
+
+func TestBuildStrategyIsOptimal(t *testing.T) {
+	dictionary, err := NewDictionary(strategyWordles, strategyNonWordles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	guesses := dictionary.guessTree.Wordles
+	strategy, err := BuildStrategy(dictionary, StrategyOptions{Breadth: len(guesses)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats := strategy.Stats()
+	if stats.Solutions != len(strategyWordles) {
+		t.Fatalf("Expected %d solutions, got %d", len(strategyWordles), stats.Solutions)
+	}
+	expected := exhaustiveCost(guesses, dictionary.solutionTree.Wordles, make(map[string]int))
+	if total := int(math.Round(stats.Average * float64(stats.Solutions))); total != expected {
+		t.Errorf("Expected %d guesses in total, got %d", expected, total)
+	}
+}
+
+func TestStrategySolvesEverySolution(t *testing.T) {
+	dictionary, err := NewDictionary(strategyWordles, strategyNonWordles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, breadth := range []int{1, 3} {
+		strategy, err := BuildStrategy(dictionary, StrategyOptions{Breadth: breadth})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, solution := range strategyWordles {
+			node := strategy.Root
+			hints := make(Pattern, len(solution))
+			for guesses := 1; ; guesses++ {
+				if node == nil || guesses > len(strategyWordles) {
+					t.Errorf("Breadth %d: strategy does not solve %s", breadth, solution)
+					break
+				}
+				scoreInto(node.Guess, solution, hints)
+				if node.Guess == solution {
+					break
+				}
+				node = node.Next(hints)
+			}
+		}
+	}
+}